@@ -0,0 +1,213 @@
+package pardon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AnswerSource supplies scripted answers for named prompts, letting a Form
+// run without a terminal.
+type AnswerSource interface {
+	// Lookup returns the answer for the prompt with the given name.
+	Lookup(name string) (any, bool)
+}
+
+// Answers is an AnswerSource backed by a map of prompt names to answers.
+// Questions, passwords, texts, editors and paths take strings, file
+// selections take the path of a file, confirmations take booleans or yes/no
+// strings, selections take an option key or value, multi-selections take a
+// list of them or a comma separated string, and dates take a time.Time or a
+// date in the prompt's layout.
+type Answers map[string]any
+
+// Lookup returns the answer stored under name.
+func (a Answers) Lookup(name string) (any, bool) {
+	v, ok := a[name]
+	return v, ok
+}
+
+// AnswersFromJSON reads answers from a JSON object keyed by prompt name.
+// Numbers are kept as json.Number so they reach prompts exactly as written.
+func AnswersFromJSON(r io.Reader) (Answers, error) {
+	var answers Answers
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&answers); err != nil {
+		return nil, fmt.Errorf("reading answers: %w", err)
+	}
+	return answers, nil
+}
+
+// AnswersFromFile reads answers from a JSON file. See AnswersFromJSON.
+func AnswersFromFile(path string) (Answers, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return AnswersFromJSON(f)
+}
+
+// envAnswers looks answers up in environment variables.
+type envAnswers struct {
+	prefix string
+}
+
+// AnswersFromEnv returns an AnswerSource reading answers from environment
+// variables named by the prefix followed by the prompt name in upper case,
+// with any character other than a letter or digit replaced by an underscore.
+// With the prefix "APP_", the prompt "db-host" is answered by APP_DB_HOST.
+func AnswersFromEnv(prefix string) AnswerSource {
+	return envAnswers{prefix: prefix}
+}
+
+// Lookup returns the value of the environment variable for name.
+func (e envAnswers) Lookup(name string) (any, bool) {
+	return os.LookupEnv(e.prefix + envName(name))
+}
+
+// envName converts a prompt name into an environment variable name.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// answerer is implemented by prompts that can be answered from an AnswerSource.
+type answerer interface {
+	promptName() string
+	answer(v any) error
+}
+
+// answerString converts a single scripted answer into text.
+func answerString(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", errors.New("answer is empty")
+	case string:
+		return v, nil
+	case []any, []string, map[string]any:
+		return "", fmt.Errorf("expected a single value, got %T", v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// answerBool converts a scripted answer into a yes or no.
+func answerBool(v any) (bool, error) {
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "true", "1":
+		return true, nil
+	case "n", "no", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not yes or no", s)
+}
+
+// answerList converts a scripted answer into a list of single answers.
+// Strings are split on commas.
+func answerList(v any) ([]any, error) {
+	switch v := v.(type) {
+	case []any:
+		return v, nil
+	case []string:
+		list := make([]any, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		return list, nil
+	case string:
+		var list []any
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		return list, nil
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return nil, err
+	}
+	return []any{s}, nil
+}
+
+// answerOption returns the index of the option matching a scripted answer,
+// comparing it with each option's key, ignoring case, and then its value.
+func answerOption[T comparable](options []Option[T], v any) (int, error) {
+	s, err := answerString(v)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, opt := range options {
+		if strings.EqualFold(opt.Key, s) {
+			return i, nil
+		}
+	}
+
+	for i, opt := range options {
+		if fmt.Sprint(opt.Value) == s {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no option matches %q", s)
+}
+
+// answerPrompts answers every prompt from src. Nothing is answered unless
+// every prompt is named and has an answer; otherwise the error lists the
+// prompts without one.
+func answerPrompts(prompts []Prompt, src AnswerSource) error {
+	var missing []string
+	values := make([]any, len(prompts))
+
+	for i, p := range prompts {
+		a, ok := p.(answerer)
+		if !ok || a.promptName() == "" {
+			missing = append(missing, fmt.Sprintf("#%d (unnamed)", i+1))
+			continue
+		}
+
+		if values[i], ok = src.Lookup(a.promptName()); !ok {
+			missing = append(missing, a.promptName())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingAnswers, strings.Join(missing, ", "))
+	}
+
+	var errs []error
+	for i, p := range prompts {
+		a := p.(answerer)
+		if err := a.answer(values[i]); err != nil {
+			errs = append(errs, fmt.Errorf("answer for %s: %w", a.promptName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
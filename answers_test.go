@@ -0,0 +1,215 @@
+package pardon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// answeredForm holds the values bound to the prompts of newAnsweredForm.
+type answeredForm struct {
+	name     string
+	password []byte
+	proceed  bool
+	color    int
+	sizes    []string
+}
+
+// newAnsweredForm builds a form with one named prompt of each kind.
+func newAnsweredForm(v *answeredForm) *Form {
+	return NewForm(
+		NewQuestion().Name("name").Title("Name?").Value(&v.name).
+			Validate(func(s string) error {
+				if s == "" {
+					return errors.New("name is required")
+				}
+				return nil
+			}),
+		NewPassword().Name("password").Title("Password:").Value(&v.password),
+		NewConfirm().Name("proceed").Title("Proceed?").Value(&v.proceed),
+		NewSelect[int]().Name("color").Title("Color:").Value(&v.color).
+			Options(NewOption("Red", 1), NewOption("Blue", 2)),
+		NewMultiSelect[string]().Name("sizes").Title("Sizes:").Value(&v.sizes).Max(2).
+			Options(NewOption("Small", "s"), NewOption("Medium", "m"), NewOption("Large", "l")),
+	)
+}
+
+func TestFormAnswersMap(t *testing.T) {
+	var v answeredForm
+
+	err := newAnsweredForm(&v).Answers(Answers{
+		"name":     "Bob",
+		"password": "s3cret",
+		"proceed":  true,
+		"color":    "blue",
+		"sizes":    []any{"small", "l"},
+	}).Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if v.name != "Bob" || string(v.password) != "s3cret" || !v.proceed || v.color != 2 {
+		t.Errorf("Ask() values = %+v", v)
+	}
+	if strings.Join(v.sizes, ",") != "s,l" {
+		t.Errorf("Ask() sizes = %v; want [s l]", v.sizes)
+	}
+}
+
+func TestFormAnswersJSON(t *testing.T) {
+	var v answeredForm
+
+	answers, err := AnswersFromJSON(strings.NewReader(`{
+		"name": "Alice",
+		"password": "pw",
+		"proceed": "no",
+		"color": 1,
+		"sizes": "medium"
+	}`))
+	if err != nil {
+		t.Fatalf("AnswersFromJSON() error = %v", err)
+	}
+
+	if err := newAnsweredForm(&v).Answers(answers).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if v.name != "Alice" || v.proceed || v.color != 1 || strings.Join(v.sizes, ",") != "m" {
+		t.Errorf("Ask() values = %+v", v)
+	}
+}
+
+func TestFormAnswersJSONNumbers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "answers.json")
+	data := `{"port": 1000000, "ratio": 0.000001, "scale": 2.5e3, "code": 12345678}`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	answers, err := AnswersFromFile(file)
+	if err != nil {
+		t.Fatalf("AnswersFromFile() error = %v", err)
+	}
+
+	var port int
+	var ratio, scale float64
+	var code string
+	err = NewForm(
+		NewNumber[int]().Name("port").Title("Port:").Value(&port),
+		NewNumber[float64]().Name("ratio").Title("Ratio:").Value(&ratio),
+		NewNumber[float64]().Name("scale").Title("Scale:").Value(&scale),
+		NewQuestion().Name("code").Title("Code:").Value(&code),
+	).Answers(answers).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if port != 1000000 || ratio != 0.000001 || scale != 2500 || code != "12345678" {
+		t.Errorf("Ask() values = %d, %v, %v, %q", port, ratio, scale, code)
+	}
+}
+
+func TestAnswerStringFloat(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{float64(1000000), "1000000"},
+		{float64(12345678), "12345678"},
+		{0.1, "0.1"},
+		{float32(2.5), "2.5"},
+	}
+
+	for _, tt := range tests {
+		if got, err := answerString(tt.v); err != nil || got != tt.want {
+			t.Errorf("answerString(%v) = %q, %v; want %q", tt.v, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormAnswersEnv(t *testing.T) {
+	var v answeredForm
+
+	t.Setenv("APP_NAME", "Carol")
+	t.Setenv("APP_PASSWORD", "pw")
+	t.Setenv("APP_PROCEED", "yes")
+	t.Setenv("APP_COLOR", "Red")
+	t.Setenv("APP_SIZES", "Small, Medium")
+
+	if err := newAnsweredForm(&v).Answers(AnswersFromEnv("APP_")).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if v.name != "Carol" || !v.proceed || v.color != 1 || strings.Join(v.sizes, ",") != "s,m" {
+		t.Errorf("Ask() values = %+v", v)
+	}
+}
+
+func TestFormAnswersMissing(t *testing.T) {
+	var v answeredForm
+	form := newAnsweredForm(&v)
+	form.prompts = append(form.prompts, &MockPrompt{})
+
+	err := form.Answers(Answers{"name": "Bob", "color": "Red"}).Ask()
+
+	if !errors.Is(err, ErrMissingAnswers) {
+		t.Fatalf("Ask() error = %v; want %v", err, ErrMissingAnswers)
+	}
+
+	want := "no answer for prompts: password, proceed, sizes, #6 (unnamed)"
+	if err.Error() != want {
+		t.Errorf("Ask() error = %q; want %q", err, want)
+	}
+
+	if v.name != "" {
+		t.Errorf("Ask() answered %q despite missing answers", v.name)
+	}
+}
+
+func TestFormAnswersInvalid(t *testing.T) {
+	var v answeredForm
+
+	err := newAnsweredForm(&v).Answers(Answers{
+		"name":     "",
+		"password": "pw",
+		"proceed":  "maybe",
+		"color":    "Green",
+		"sizes":    "small,medium,large",
+	}).Ask()
+
+	if err == nil {
+		t.Fatal("Ask() error = nil; want validation errors")
+	}
+
+	for _, want := range []string{
+		"answer for name: name is required",
+		`answer for proceed: "maybe" is not yes or no`,
+		`answer for color: no option matches "Green"`,
+		"answer for sizes: select at most 2 options",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Ask() error does not contain %q\nError: %v", want, err)
+		}
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"name", "NAME"},
+		{"db-host", "DB_HOST"},
+		{"db.port2", "DB_PORT2"},
+		{"héllo", "H_LLO"},
+	}
+
+	for _, tt := range tests {
+		if got := envName(tt.name); got != tt.want {
+			t.Errorf("envName(%q) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -15,43 +15,49 @@ var Icons = icons{
 	Password:     "🔒 ",
 }
 
+// funcs holds the default styling functions of a Session.
 type funcs struct {
 	answerFn func(string) string
 	cursorFn func(string) string
 	iconFn   func(string) string
+	matchFn  func(string) string
 	selectFn func(string) string
 	titleFn  func(string) string
 }
 
-var defaultFuncs = funcs{
-	answerFn: nil,
-	cursorFn: nil,
-	iconFn:   nil,
-	selectFn: nil,
-	titleFn:  nil,
+// SetAccessible turns accessible mode on or off for prompts in the default
+// session. See Session.SetAccessible.
+func SetAccessible(on bool) {
+	defaultSession.SetAccessible(on)
 }
 
 // SetDefaultAnswerFunc sets the global default answer transformation function.
 func SetDefaultAnswerFunc(fn func(string) string) {
-	defaultFuncs.answerFn = fn
+	defaultSession.SetDefaultAnswerFunc(fn)
 }
 
 // SetDefaultCursorFunc sets the global default cursor formatting function.
 func SetDefaultCursorFunc(fn func(string) string) {
-	defaultFuncs.cursorFn = fn
+	defaultSession.SetDefaultCursorFunc(fn)
 }
 
 // SetDefaultIconFunc sets the global default icon transformation function.
 func SetDefaultIconFunc(fn func(string) string) {
-	defaultFuncs.iconFn = fn
+	defaultSession.SetDefaultIconFunc(fn)
+}
+
+// SetDefaultMatchFunc sets the global default function used to highlight
+// characters matched by a filter.
+func SetDefaultMatchFunc(fn func(string) string) {
+	defaultSession.SetDefaultMatchFunc(fn)
 }
 
 // SetDefaultSelectFunc sets the global default selection formatting function.
 func SetDefaultSelectFunc(fn func(string) string) {
-	defaultFuncs.selectFn = fn
+	defaultSession.SetDefaultSelectFunc(fn)
 }
 
 // SetDefaultTitleFunc sets the global default title transformation function.
 func SetDefaultTitleFunc(fn func(string) string) {
-	defaultFuncs.titleFn = fn
+	defaultSession.SetDefaultTitleFunc(fn)
 }
@@ -1,10 +1,19 @@
 package pardon
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
 
 var (
-	ErrUserAborted     = errors.New("user aborted")
+	ErrUserAborted     = tui.ErrUserAborted
 	ErrNoTitle         = errors.New("prompt requires a title")
 	ErrNoSelectOptions = errors.New("select prompt requires at least one option")
 	ErrNoValue         = errors.New("value must be set")
+	ErrInvalidLimits   = errors.New("selection limits are negative or out of range")
+	ErrInvalidRange    = errors.New("minimum is greater than maximum")
+	ErrEndOfInput      = tui.ErrEndOfInput
+	ErrInterrupted     = tui.ErrInterrupted
+	ErrMissingAnswers  = errors.New("no answer for prompts")
 )
@@ -1,7 +1,11 @@
 package pardon
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
 )
 
 func TestErrors(t *testing.T) {
@@ -30,6 +34,16 @@ func TestErrors(t *testing.T) {
 			err:      ErrNoValue,
 			expected: "value must be set",
 		},
+		{
+			name:     "invalid range error",
+			err:      ErrInvalidRange,
+			expected: "minimum is greater than maximum",
+		},
+		{
+			name:     "end of input error",
+			err:      ErrEndOfInput,
+			expected: "input ended before the prompt was answered",
+		},
 	}
 
 	for _, tt := range tests {
@@ -41,6 +55,40 @@ func TestErrors(t *testing.T) {
 	}
 }
 
+func TestPromptsUserAborted(t *testing.T) {
+	var (
+		n    int
+		text string
+		path string
+	)
+
+	tests := []struct {
+		name string
+		ask  func(*pardontest.Terminal) error
+	}{
+		{"number", func(term *pardontest.Terminal) error {
+			return NewNumber[int]().Title("N?").Value(&n).Terminal(term).Ask()
+		}},
+		{"text", func(term *pardontest.Terminal) error {
+			return NewText().Title("Notes:").Value(&text).Terminal(term).Ask()
+		}},
+		{"path", func(term *pardontest.Terminal) error {
+			return NewPath().Title("Path:").Value(&path).Terminal(term).Ask()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := pardontest.NewTerminal(40, 10)
+			term.Send(keys.Ctrl('c'))
+
+			if err := tt.ask(term); !errors.Is(err, ErrUserAborted) {
+				t.Errorf("Ask() error = %v; want %v", err, ErrUserAborted)
+			}
+		})
+	}
+}
+
 func TestErrorsAreNotNil(t *testing.T) {
 	errors := []error{
 		ErrUserAborted,
@@ -8,8 +8,15 @@ type Example struct {
 var AllExamples = []Example{
 	{"Confirm - Basic", ConfirmBasic},
 	{"Confirm - Kitchen Sink", ConfirmKitchensink},
+	{"Confirm - Timeout", ConfirmTimeout},
+	{"Date - Basic", DateBasic},
+	{"Editor - Basic", EditorBasic},
+	{"FileSelect - Basic", FileSelectBasic},
 	{"Form - Basic", FormBasic},
 	{"Form - Validate", FormValidate},
+	{"MultiSelect - Basic", MultiSelectBasic},
+	{"Number - Basic", NumberBasic},
+	{"Path - Basic", PathBasic},
 	{"Password - Basic", PasswordBasic},
 	{"Password - Validate", PasswordValidate},
 	{"Password - Kitchen Sink", PasswordKitchesink},
@@ -18,5 +25,7 @@ var AllExamples = []Example{
 	{"Question - Kitchen Sink", QuestionKitchensink},
 	{"Select - Basic", SelectBasic},
 	{"Select - Struct", SelectStruct},
+	{"Select - Filter", SelectFilter},
 	{"Select - Kitchen Sink", SelectKitchensink},
+	{"Text - Basic", TextBasic},
 }
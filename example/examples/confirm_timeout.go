@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func ConfirmTimeout() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	proceed := false
+	confirm := pardon.NewConfirm().
+		Title("Continue? (5 seconds to answer)").
+		Value(&proceed)
+
+	err := confirm.AskContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		fmt.Println("No answer given, continuing without confirmation")
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Continue: %t\n", proceed)
+
+	os.Exit(0)
+}
@@ -0,0 +1,28 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func DateBasic() {
+	var appointment time.Time
+	date := pardon.NewDateTime().
+		Title("When should we book it?").
+		Min(time.Now()).
+		Max(time.Now().AddDate(0, 3, 0)).
+		Value(&appointment)
+
+	if err := date.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Booked for %s%s%s\n", ansi.Green, appointment.Format("Monday, January 2 at 15:04"), ansi.Reset)
+
+	os.Exit(0)
+}
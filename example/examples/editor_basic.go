@@ -0,0 +1,34 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func EditorBasic() {
+	var notes string
+	editor := pardon.NewEditor().
+		Title("Release notes:").
+		Extension(".md").
+		Validate(func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return errors.New("write at least one line")
+			}
+			return nil
+		}).
+		Value(&notes)
+
+	if err := editor.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Release notes:\n%s%s%s\n", ansi.Green, notes, ansi.Reset)
+
+	os.Exit(0)
+}
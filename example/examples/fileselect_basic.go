@@ -0,0 +1,26 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func FileSelectBasic() {
+	var source string
+	fileSelect := pardon.NewFileSelect().
+		Title("Pick a Go file:").
+		Glob("*.go").
+		Value(&source)
+
+	if err := fileSelect.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Opening %s%s%s\n", ansi.Green, source, ansi.Reset)
+
+	os.Exit(0)
+}
@@ -3,37 +3,22 @@ package examples
 import (
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/engmtcdrm/go-pardon"
 )
 
 func FormValidate() {
 	continueFlag := true
-	age := ""
+	age := 0
 
 	f := pardon.NewForm(
 		pardon.NewConfirm().
 			Title("Are you sure you want to proceed?").
 			Value(&continueFlag),
-		pardon.NewQuestion().
+		pardon.NewNumber[int]().
 			Title("How old are you?").
-			Validate(func(answer string) error {
-				if answer == "" {
-					return fmt.Errorf("age cannot be empty")
-				}
-
-				answerInt, err := strconv.Atoi(answer)
-				if err != nil {
-					return fmt.Errorf("invalid age")
-				}
-
-				if answerInt < 0 {
-					return fmt.Errorf("age cannot be negative")
-				}
-
-				return nil
-			}).
+			Min(0).
+			Max(150).
 			Value(&age),
 	)
 
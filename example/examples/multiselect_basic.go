@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func MultiSelectBasic() {
+	selectedColors := []int{}
+	colors := []pardon.Option[int]{}
+	colors = append(colors, pardon.Option[int]{Key: "Red", Value: 1})
+	colors = append(colors, pardon.Option[int]{Key: "Blue", Value: 2})
+	colors = append(colors, pardon.Option[int]{Key: "Green", Value: 3})
+	colors = append(colors, pardon.Option[int]{Key: "Yellow", Value: 4})
+
+	selectPrompt := pardon.NewMultiSelect[int]().
+		Title("Choose your colors (space to toggle, a for all, i to invert):").
+		Options(colors...).
+		Min(1).
+		Max(3).
+		Value(&selectedColors)
+
+	if err := selectPrompt.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Selected options: %v\n", selectedColors)
+	os.Exit(0)
+}
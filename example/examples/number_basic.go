@@ -0,0 +1,27 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func NumberBasic() {
+	servings := 2
+	number := pardon.NewNumber[int]().
+		Title("How many servings?").
+		Min(1).
+		Max(12).
+		Value(&servings)
+
+	if err := number.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Cooking for %s%d%s\n", ansi.Green, servings, ansi.Reset)
+
+	os.Exit(0)
+}
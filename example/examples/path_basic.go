@@ -0,0 +1,28 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func PathBasic() {
+	var config string
+	path := pardon.NewPath().
+		Title("Config file:").
+		Extensions(".yaml", ".yml").
+		FilesOnly(true).
+		MustExist(true).
+		Value(&config)
+
+	if err := path.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Loading %s%s%s\n", ansi.Green, config, ansi.Reset)
+
+	os.Exit(0)
+}
@@ -0,0 +1,35 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func SelectFilter() {
+	var region string
+	regions := []pardon.Option[string]{}
+	for _, r := range []string{
+		"af-south-1", "ap-east-1", "ap-northeast-1", "ap-northeast-2", "ap-south-1",
+		"ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-north-1",
+		"eu-south-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1",
+		"sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2",
+	} {
+		regions = append(regions, pardon.NewOption(r, r))
+	}
+
+	selectPrompt := pardon.NewSelect[string]().
+		Title("Choose a region (type to filter):").
+		Filter(true).
+		Options(regions...).
+		Value(&region)
+
+	if err := selectPrompt.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Selected region: %v\n", region)
+	os.Exit(0)
+}
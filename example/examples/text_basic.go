@@ -0,0 +1,27 @@
+package examples
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon"
+)
+
+func TextBasic() {
+	var address string
+	text := pardon.NewText().
+		Title("Shipping address:").
+		Height(4).
+		MaxLines(6).
+		Value(&address)
+
+	if err := text.Ask(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Shipping to:\n%s%s%s\n", ansi.Green, address, ansi.Reset)
+
+	os.Exit(0)
+}
@@ -1,8 +1,16 @@
 package pardon
 
+import (
+	"context"
+	"fmt"
+)
+
 // Form represents a collection of prompts executed sequentially.
 type Form struct {
 	prompts []Prompt
+	session *Session
+	term    Terminal
+	answers AnswerSource
 }
 
 // NewForm creates a new Form with the given prompts.
@@ -13,10 +21,58 @@ func NewForm(prompts ...Prompt) *Form {
 	return f
 }
 
+// Terminal sets the terminal used by every prompt in the form.
+func (f *Form) Terminal(t Terminal) *Form {
+	f.term = t
+	return f
+}
+
+// Session moves every prompt in the form to s when asked, so they use its
+// terminal and default styles. A terminal set with Terminal still applies.
+func (f *Form) Session(s *Session) *Form {
+	f.session = s
+	return f
+}
+
+// Answers sets a source of scripted answers. The form then answers every
+// prompt by name from src instead of asking, failing if any prompt has no
+// answer or an answer doesn't pass validation.
+func (f *Form) Answers(src AnswerSource) *Form {
+	f.answers = src
+	return f
+}
+
 // Ask executes all prompts in sequence, stopping on the first error.
 func (f *Form) Ask() error {
+	return f.AskContext(context.Background())
+}
+
+// AskContext executes all prompts in sequence until one fails or ctx is
+// done. Prompts that don't support contexts are only checked between asks.
+func (f *Form) AskContext(ctx context.Context) error {
+	if f.answers != nil {
+		return answerPrompts(f.prompts, f.answers)
+	}
+
 	for _, p := range f.prompts {
-		if err := p.Ask(); err != nil {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("form canceled: %w", err)
+		}
+
+		if ss, ok := p.(sessionSetter); ok && f.session != nil {
+			ss.setSession(f.session)
+		}
+		if ts, ok := p.(terminalSetter); ok && f.term != nil {
+			ts.setTerminal(f.term)
+		}
+
+		var err error
+		if ca, ok := p.(contextAsker); ok {
+			err = ca.AskContext(ctx)
+		} else {
+			err = p.Ask()
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -1,8 +1,11 @@
 package pardon
 
 import (
+	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 )
 
 // MockPrompt is a test double for the Prompt interface
@@ -226,3 +229,41 @@ func TestForm_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestFormAskContext(t *testing.T) {
+	t.Run("does not ask once the context is done", func(t *testing.T) {
+		mockPrompt := &MockPrompt{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := NewForm(mockPrompt).AskContext(ctx)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("AskContext() error = %v; want %v", err, context.Canceled)
+		}
+		if mockPrompt.askCalled {
+			t.Error("Prompt was asked after the context was cancelled")
+		}
+	})
+
+	t.Run("cancels the prompt being asked", func(t *testing.T) {
+		var name string
+		r, w := io.Pipe()
+		defer w.Close()
+
+		mockPrompt := &MockPrompt{}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := NewForm(NewQuestion().Title("Name?").Value(&name), mockPrompt).
+			Terminal(NewStreamTerminal(r, io.Discard)).
+			AskContext(ctx)
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("AskContext() error = %v; want %v", err, context.DeadlineExceeded)
+		}
+		if mockPrompt.askCalled {
+			t.Error("Prompt after the cancelled one was asked")
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package pardon
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyMatch reports whether every rune of pattern appears in text in order,
+// ignoring case. It returns the rune indexes of text that matched and a score
+// favouring contiguous runs and matches at the start of words.
+func fuzzyMatch(pattern, text string) ([]int, int, bool) {
+	p := lowerRunes(pattern)
+	t := lowerRunes(text)
+
+	if len(p) == 0 {
+		return nil, 0, true
+	}
+
+	var best []int
+	bestScore := -1
+
+	// Try every possible starting point for the first rune and keep the
+	// greedy match with the highest score.
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+
+		positions := make([]int, 0, len(p))
+		positions = append(positions, start)
+		pi := 1
+
+		for ti := start + 1; ti < len(t) && pi < len(p); ti++ {
+			if t[ti] == p[pi] {
+				positions = append(positions, ti)
+				pi++
+			}
+		}
+
+		if pi < len(p) {
+			// Later starting points cannot match either
+			break
+		}
+
+		if score := fuzzyScore(t, positions); score > bestScore {
+			best = positions
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return nil, 0, false
+	}
+
+	return best, bestScore, true
+}
+
+// lowerRunes lowercases s rune by rune so indexes line up with the original text.
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// fuzzyScore rates a set of matched positions within text.
+func fuzzyScore(text []rune, positions []int) int {
+	score := 0
+
+	for i, pos := range positions {
+		score++
+
+		if i > 0 && positions[i-1] == pos-1 {
+			score += 5
+		}
+
+		if pos == 0 || isWordSeparator(text[pos-1]) {
+			score += 3
+		}
+	}
+
+	return score
+}
+
+// isWordSeparator reports whether r separates words within an option label.
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("-_./:", r)
+}
+
+// fuzzyFilter returns the indexes of the keys matching pattern, ordered by
+// descending score, along with the matched rune positions for each result.
+func fuzzyFilter(pattern string, keys []string) ([]int, [][]int) {
+	type result struct {
+		index     int
+		score     int
+		positions []int
+	}
+
+	results := make([]result, 0, len(keys))
+	for i, key := range keys {
+		if positions, score, ok := fuzzyMatch(pattern, key); ok {
+			results = append(results, result{index: i, score: score, positions: positions})
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].score > results[b].score
+	})
+
+	indexes := make([]int, len(results))
+	matches := make([][]int, len(results))
+	for i, r := range results {
+		indexes[i] = r.index
+		matches[i] = r.positions
+	}
+
+	return indexes, matches
+}
+
+// highlightMatches applies fn to the runes of s at the given positions,
+// grouping adjacent positions into a single call.
+func highlightMatches(s string, positions []int, fn func(string) string) string {
+	if len(positions) == 0 {
+		return s
+	}
+
+	runes := []rune(s)
+	var b strings.Builder
+	next := 0
+
+	for i := 0; i < len(runes); {
+		if next < len(positions) && positions[next] == i {
+			j := i
+			for next < len(positions) && positions[next] == j {
+				next++
+				j++
+			}
+			b.WriteString(fn(string(runes[i:j])))
+			i = j
+			continue
+		}
+
+		b.WriteRune(runes[i])
+		i++
+	}
+
+	return b.String()
+}
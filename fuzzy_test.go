@@ -0,0 +1,80 @@
+package pardon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern, text string
+		wantPositions []int
+		wantOK        bool
+	}{
+		{"empty pattern", "", "anything", nil, true},
+		{"exact prefix", "us", "us-east-1", []int{0, 1}, true},
+		{"case insensitive", "UE", "us-east-1", []int{0, 3}, true},
+		{"subsequence", "ue1", "us-east-1", []int{0, 3, 8}, true},
+		{"prefers contiguous run", "west", "us-west-2", []int{3, 4, 5, 6}, true},
+		{"prefers word start", "e1", "eee-e1", []int{4, 5}, true},
+		{"out of order", "tsu", "us-east-1", nil, false},
+		{"no match", "xyz", "us-east-1", nil, false},
+		{"multi-byte runes", "本", "日本語", []int{1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions, _, ok := fuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("fuzzyMatch(%q, %q) ok = %t; want %t", tt.pattern, tt.text, ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("fuzzyMatch(%q, %q) positions = %v; want %v", tt.pattern, tt.text, positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	keys := []string{"eu-west-1", "us-east-1", "us-west-2", "ap-south-1"}
+
+	indexes, matches := fuzzyFilter("west", keys)
+
+	if !reflect.DeepEqual(indexes, []int{0, 2}) {
+		t.Errorf("fuzzyFilter() indexes = %v; want %v", indexes, []int{0, 2})
+	}
+
+	if len(matches) != len(indexes) {
+		t.Fatalf("fuzzyFilter() returned %d match sets for %d results", len(matches), len(indexes))
+	}
+
+	indexes, _ = fuzzyFilter("us", keys)
+	if !reflect.DeepEqual(indexes, []int{1, 2, 0}) {
+		t.Errorf("fuzzyFilter() should rank word-start matches first, got %v", indexes)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	wrap := func(s string) string { return "[" + s + "]" }
+
+	tests := []struct {
+		name      string
+		s         string
+		positions []int
+		want      string
+	}{
+		{"no positions", "green", nil, "green"},
+		{"single rune", "green", []int{0}, "[g]reen"},
+		{"adjacent runs grouped", "green", []int{1, 2, 4}, "g[re]e[n]"},
+		{"multi-byte runes", "日本語", []int{1, 2}, "日[本語]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightMatches(tt.s, tt.positions, wrap); got != tt.want {
+				t.Errorf("highlightMatches(%q, %v) = %q; want %q", tt.s, tt.positions, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,7 +6,6 @@ toolchain go1.24.3
 
 require (
 	github.com/engmtcdrm/go-ansi v1.0.1
+	golang.org/x/sys v0.35.0
 	golang.org/x/term v0.34.0
 )
-
-require golang.org/x/sys v0.35.0 // indirect
@@ -0,0 +1,160 @@
+package keys
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Key identifies the key pressed in a decoded Event.
+type Key int
+
+const (
+	// Rune is a character key; the character is held in Event.Rune.
+	Rune Key = iota
+	Enter
+	Tab
+	Backspace
+	Escape
+	Insert
+	Delete
+	Up
+	Down
+	Right
+	Left
+	Home
+	End
+	PageUp
+	PageDown
+	F1
+	F2
+	F3
+	F4
+	F5
+	F6
+	F7
+	F8
+	F9
+	F10
+	F11
+	F12
+
+	// Resize is not a key press; it is delivered when the terminal window
+	// changes size so prompts can lay themselves out again.
+	Resize
+
+	// Resume is not a key press; it is delivered after the process was
+	// suspended and continued, as the screen may have changed meanwhile and
+	// prompts need to draw themselves again.
+	Resume
+)
+
+// keyNames holds the display names of the named keys.
+var keyNames = map[Key]string{
+	Enter:     "enter",
+	Tab:       "tab",
+	Backspace: "backspace",
+	Escape:    "esc",
+	Insert:    "insert",
+	Delete:    "delete",
+	Up:        "up",
+	Down:      "down",
+	Right:     "right",
+	Left:      "left",
+	Home:      "home",
+	End:       "end",
+	PageUp:    "pgup",
+	PageDown:  "pgdown",
+	Resize:    "resize",
+	Resume:    "resume",
+}
+
+// String returns the lowercase name of the key, such as "enter" or "f5".
+func (k Key) String() string {
+	if k >= F1 && k <= F12 {
+		return "f" + strconv.Itoa(int(k-F1)+1)
+	}
+
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+
+	if k == Rune {
+		return "rune"
+	}
+
+	return "key(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Mod is a set of modifier keys held during a key press.
+type Mod uint8
+
+const (
+	ModShift Mod = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// Event is a single decoded key press.
+type Event struct {
+	Key  Key  // Named key, or Rune for character keys
+	Rune rune // Character for Rune events
+	Mod  Mod  // Modifiers held with the key
+}
+
+// Char returns the event produced by typing r.
+func Char(r rune) Event {
+	return Event{Key: Rune, Rune: r}
+}
+
+// Ctrl returns the event produced by pressing Ctrl together with the letter r.
+func Ctrl(r rune) Event {
+	return Event{Key: Rune, Rune: r, Mod: ModCtrl}
+}
+
+// Alt returns the event produced by pressing Alt together with r.
+func Alt(r rune) Event {
+	return Event{Key: Rune, Rune: r, Mod: ModAlt}
+}
+
+// Named returns the event produced by pressing the named key k with mods held.
+func Named(k Key, mods ...Mod) Event {
+	ev := Event{Key: k}
+	for _, m := range mods {
+		ev.Mod |= m
+	}
+	return ev
+}
+
+// IsPrintable reports whether the event is a character that should be
+// inserted as text, i.e. a rune typed without Ctrl or Alt.
+func (e Event) IsPrintable() bool {
+	return e.Key == Rune && e.Mod&(ModCtrl|ModAlt) == 0 && e.Rune >= ' ' && e.Rune != 127
+}
+
+// String returns a readable description of the event such as "ctrl+c",
+// "alt+b", "shift+tab" or "a".
+func (e Event) String() string {
+	var b strings.Builder
+
+	if e.Mod&ModCtrl != 0 {
+		b.WriteString("ctrl+")
+	}
+	if e.Mod&ModAlt != 0 {
+		b.WriteString("alt+")
+	}
+	if e.Mod&ModShift != 0 {
+		b.WriteString("shift+")
+	}
+
+	if e.Key == Rune {
+		if e.Rune == ' ' {
+			b.WriteString("space")
+		} else {
+			b.WriteRune(e.Rune)
+		}
+	} else {
+		b.WriteString(e.Key.String())
+	}
+
+	return b.String()
+}
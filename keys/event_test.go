@@ -0,0 +1,76 @@
+package keys
+
+import "testing"
+
+func TestEventConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Event
+		want Event
+	}{
+		{"Char", Char('x'), Event{Key: Rune, Rune: 'x'}},
+		{"Ctrl", Ctrl('c'), Event{Key: Rune, Rune: 'c', Mod: ModCtrl}},
+		{"Alt", Alt('b'), Event{Key: Rune, Rune: 'b', Mod: ModAlt}},
+		{"Named", Named(Up), Event{Key: Up}},
+		{"Named with modifiers", Named(Tab, ModShift, ModCtrl), Event{Key: Tab, Mod: ModShift | ModCtrl}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %+v; want %+v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventIsPrintable(t *testing.T) {
+	tests := []struct {
+		name string
+		ev   Event
+		want bool
+	}{
+		{"letter", Char('a'), true},
+		{"space", Char(' '), true},
+		{"multi-byte rune", Char('é'), true},
+		{"shifted letter", Event{Key: Rune, Rune: 'A', Mod: ModShift}, true},
+		{"ctrl letter", Ctrl('a'), false},
+		{"alt letter", Alt('b'), false},
+		{"named key", Named(Enter), false},
+		{"control rune", Char('\t'), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ev.IsPrintable(); got != tt.want {
+				t.Errorf("IsPrintable() = %t; want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventString(t *testing.T) {
+	tests := []struct {
+		ev   Event
+		want string
+	}{
+		{Char('a'), "a"},
+		{Char(' '), "space"},
+		{Ctrl('c'), "ctrl+c"},
+		{Alt('f'), "alt+f"},
+		{Named(Tab, ModShift), "shift+tab"},
+		{Named(Up, ModCtrl, ModAlt), "ctrl+alt+up"},
+		{Named(Escape), "esc"},
+		{Named(PageDown), "pgdown"},
+		{Named(F1), "f1"},
+		{Named(F12), "f12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.ev.String(); got != tt.want {
+				t.Errorf("String() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package keys
 
 const (
-	// Key codes for navigation and actions
+	// Raw byte codes for navigation and actions as read from the terminal.
+	// Prompts match on decoded Events; see Event and the named Key values.
 	KeyCtrlC          = byte(3)
 	KeyDelete         = byte(8)
 	KeyCarriageReturn = byte(10) // Additional for cross-platform compatibility
@@ -0,0 +1,136 @@
+package pardon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// errNoChoice is shown when a line-based choice is left empty.
+var errNoChoice = errors.New("enter the number or name of an option")
+
+// renderNumberedOptions writes the numbered option list shown by line-based
+// choice prompts, with an optional marker before each option.
+func renderNumberedOptions(t Terminal, labels []string, marker func(i int) string) {
+	width := len(strconv.Itoa(len(labels)))
+
+	var output strings.Builder
+	for i, label := range labels {
+		fmt.Fprintf(&output, "  %*d) %s%s\n", width, i+1, marker(i), label)
+	}
+	fmt.Fprint(t, output.String())
+}
+
+// chooseOption resolves a typed choice to an option index. The choice may be
+// a 1-based option number, an option key, or a prefix of exactly one key.
+// Keys are matched case-insensitively.
+func chooseOption(choice string, labels []string) (int, error) {
+	choice = strings.TrimSpace(choice)
+	if choice == "" {
+		return 0, errNoChoice
+	}
+
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n < 1 || n > len(labels) {
+			return 0, fmt.Errorf("%d is not between 1 and %d", n, len(labels))
+		}
+		return n - 1, nil
+	}
+
+	var matches []int
+	for i, label := range labels {
+		switch {
+		case strings.EqualFold(label, choice):
+			return i, nil
+		case hasPrefixFold(label, choice):
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no option matches %q", choice)
+	case 1:
+		return matches[0], nil
+	}
+
+	names := make([]string, len(matches))
+	for i, idx := range matches {
+		names[i] = labels[idx]
+	}
+	return 0, fmt.Errorf("%q matches more than one option: %s", choice, strings.Join(names, ", "))
+}
+
+// hasPrefixFold reports whether s begins with prefix, ignoring case.
+func hasPrefixFold(s, prefix string) bool {
+	n := 0
+	for range utf8.RuneCountInString(prefix) {
+		_, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 {
+			return false
+		}
+		n += size
+	}
+	return strings.EqualFold(s[:n], prefix)
+}
+
+// lineAsker reads answers a line at a time, for terminals that are not
+// interactive and for accessible mode.
+type lineAsker struct {
+	term       Terminal
+	accessible bool // Choices are announced and errors written as plain text
+	echoed     bool // The terminal echoes lines as they are typed
+}
+
+// newLineAsker returns a lineAsker for a prompt on t in session s. Only
+// accessible mode reads lines from interactive terminals, which echo them.
+func newLineAsker(t Terminal, s *Session) lineAsker {
+	return lineAsker{term: t, accessible: s.Accessible(), echoed: tui.IsInteractive(t)}
+}
+
+// read prints prompt and reads a line of input. Unless the terminal echoed
+// it, the input is printed after the prompt by accept or reject.
+func (l lineAsker) read(ctx context.Context, prompt string) (string, error) {
+	fmt.Fprint(l.term, prompt)
+
+	text, err := tui.ReadLine(ctx, l.term)
+	if err != nil {
+		fmt.Fprintln(l.term)
+	}
+	return text, err
+}
+
+// reject completes the prompt line with the rejected input and shows why.
+func (l lineAsker) reject(text string, err error) {
+	if !l.echoed {
+		fmt.Fprintln(l.term, text)
+	}
+
+	if l.accessible {
+		fmt.Fprintln(l.term, tui.FormatPlainError(err.Error()))
+	} else {
+		fmt.Fprintln(l.term, tui.FormatError(err.Error()))
+	}
+}
+
+// accept completes the prompt line with answer, the styled answer. In
+// accessible mode the input is kept and the choice is announced instead.
+func (l lineAsker) accept(text, answer, choice string) {
+	if !l.accessible {
+		fmt.Fprintln(l.term, answer)
+		return
+	}
+
+	if !l.echoed {
+		fmt.Fprintln(l.term, text)
+	}
+	if choice == "" {
+		choice = "nothing"
+	}
+	fmt.Fprintf(l.term, "Selected: %s\n", choice)
+}
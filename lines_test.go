@@ -0,0 +1,229 @@
+package pardon
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChooseOption(t *testing.T) {
+	labels := []string{"Red", "Green", "Grey", "Blue", "Blue Green"}
+
+	tests := []struct {
+		name    string
+		choice  string
+		want    int
+		wantErr string
+	}{
+		{"number", "2", 1, ""},
+		{"number with spaces", " 4 ", 3, ""},
+		{"number too small", "0", 0, "0 is not between 1 and 5"},
+		{"number too large", "6", 0, "6 is not between 1 and 5"},
+		{"exact key", "red", 0, ""},
+		{"exact key that is also a prefix", "blue", 3, ""},
+		{"ambiguous prefix", "gre", 0, `"gre" matches more than one option: Green, Grey`},
+		{"unique longer prefix", "gree", 1, ""},
+		{"no match", "purple", 0, `no option matches "purple"`},
+		{"empty", "  ", 0, errNoChoice.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := chooseOption(tt.choice, labels)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("chooseOption(%q) error = %v; want %q", tt.choice, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("chooseOption(%q) error = %v", tt.choice, err)
+			}
+			if got != tt.want {
+				t.Errorf("chooseOption(%q) = %d; want %d", tt.choice, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasPrefixFold(t *testing.T) {
+	tests := []struct {
+		s, prefix string
+		want      bool
+	}{
+		{"Green", "gr", true},
+		{"Green", "GREEN", true},
+		{"Green", "Greens", false},
+		{"Élan", "él", true},
+		{"Red", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := hasPrefixFold(tt.s, tt.prefix); got != tt.want {
+			t.Errorf("hasPrefixFold(%q, %q) = %t; want %t", tt.s, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestQuestionLines(t *testing.T) {
+	var out bytes.Buffer
+	result := "Anon"
+
+	err := NewQuestion().
+		Title("Name?").
+		Value(&result).
+		Validate(func(s string) error {
+			if len(s) < 3 {
+				return errors.New("name is too short")
+			}
+			return nil
+		}).
+		Terminal(NewLineTerminal(strings.NewReader("Al\nAlice\n"), &out)).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != "Alice" {
+		t.Errorf("Ask() value = %q; want %q", result, "Alice")
+	}
+	if !strings.Contains(out.String(), "name is too short") {
+		t.Errorf("Output does not contain the validation error\nOutput: %q", out.String())
+	}
+}
+
+func TestQuestionLinesKeepsDefault(t *testing.T) {
+	result := "Anon"
+
+	err := NewQuestion().
+		Title("Name?").
+		Value(&result).
+		Terminal(NewLineTerminal(strings.NewReader("\n"), &bytes.Buffer{})).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != "Anon" {
+		t.Errorf("Ask() value = %q; want %q", result, "Anon")
+	}
+}
+
+func TestPasswordLines(t *testing.T) {
+	var out bytes.Buffer
+	var result []byte
+
+	err := NewPassword().
+		Title("Password:").
+		Value(&result).
+		Terminal(NewLineTerminal(strings.NewReader("s3cret"), &out)).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if string(result) != "s3cret" {
+		t.Errorf("Ask() value = %q; want %q", result, "s3cret")
+	}
+	if strings.Contains(out.String(), "s3cret") {
+		t.Errorf("Output shows the password\nOutput: %q", out.String())
+	}
+}
+
+func TestConfirmLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		initial bool
+		want    bool
+	}{
+		{"y", "y\n", false, true},
+		{"yes", "YES\n", false, true},
+		{"n", "n\n", true, false},
+		{"no", "No\r\n", true, false},
+		{"empty keeps default", "\n", true, true},
+		{"retries after invalid answer", "maybe\nyes\n", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.initial
+			err := NewConfirm().
+				Title("Continue?").
+				Value(&result).
+				Terminal(NewLineTerminal(strings.NewReader(tt.input), &bytes.Buffer{})).
+				Ask()
+
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Ask() value = %t; want %t", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectLines(t *testing.T) {
+	var out bytes.Buffer
+	var result int
+
+	err := NewSelect[int]().
+		Title("Choose a color:").
+		Options(NewOption("Red", 1), NewOption("Green", 2), NewOption("Grey", 3)).
+		Value(&result).
+		Terminal(NewLineTerminal(strings.NewReader("7\ngr\ngre\ngrey\n"), &out)).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != 3 {
+		t.Errorf("Ask() value = %d; want 3", result)
+	}
+
+	for _, want := range []string{"  1) Red\n", "  3) Grey\n", "7 is not between 1 and 3", "matches more than one option"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output does not contain %q\nOutput: %q", want, out.String())
+		}
+	}
+}
+
+func TestMultiSelectLines(t *testing.T) {
+	var out bytes.Buffer
+	result := []int{}
+
+	err := NewMultiSelect[int]().
+		Title("Choose colors:").
+		Options(NewOption("Red", 1), NewOption("Green", 2), NewOption("Blue", 3)).
+		Value(&result).
+		Max(2).
+		Terminal(NewLineTerminal(strings.NewReader("1,2,3\n3, red\n"), &out)).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if len(result) != 2 || result[0] != 1 || result[1] != 3 {
+		t.Errorf("Ask() value = %v; want [1 3]", result)
+	}
+	if !strings.Contains(out.String(), "select at most 2 options") {
+		t.Errorf("Output does not contain the limit error\nOutput: %q", out.String())
+	}
+}
+
+func TestLinesEndOfInput(t *testing.T) {
+	var result int
+
+	err := NewSelect[int]().
+		Title("Choose a color:").
+		Options(NewOption("Red", 1), NewOption("Blue", 2)).
+		Value(&result).
+		Terminal(NewLineTerminal(strings.NewReader("purple\n"), &bytes.Buffer{})).
+		Ask()
+
+	if !errors.Is(err, ErrEndOfInput) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrEndOfInput)
+	}
+}
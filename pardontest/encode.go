@@ -0,0 +1,110 @@
+package pardontest
+
+import (
+	"strconv"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// csiFinals holds the final byte of the xterm sequences for named keys.
+var csiFinals = map[keys.Key]byte{
+	keys.Up:    'A',
+	keys.Down:  'B',
+	keys.Right: 'C',
+	keys.Left:  'D',
+	keys.Home:  'H',
+	keys.End:   'F',
+	keys.F1:    'P',
+	keys.F2:    'Q',
+	keys.F3:    'R',
+	keys.F4:    'S',
+}
+
+// tildeCodes holds the numeric parameter of "ESC [ n ~" sequences for named keys.
+var tildeCodes = map[keys.Key]int{
+	keys.Insert:   2,
+	keys.Delete:   3,
+	keys.PageUp:   5,
+	keys.PageDown: 6,
+	keys.F5:       15,
+	keys.F6:       17,
+	keys.F7:       18,
+	keys.F8:       19,
+	keys.F9:       20,
+	keys.F10:      21,
+	keys.F11:      23,
+	keys.F12:      24,
+}
+
+// encode returns the bytes an xterm-compatible terminal sends for ev.
+func encode(ev keys.Event) []byte {
+	if ev.Key == keys.Rune {
+		var b []byte
+		r := ev.Rune
+		if ev.Mod&keys.ModCtrl != 0 {
+			switch {
+			case r >= 'a' && r <= 'z':
+				r = r - 'a' + 1
+			case r >= '@' && r <= '_':
+				r -= '@'
+			case r == ' ':
+				r = 0
+			}
+		}
+		if ev.Mod&keys.ModAlt != 0 {
+			b = append(b, keys.KeyEscape)
+		}
+		return append(b, string(r)...)
+	}
+
+	switch ev.Key {
+	case keys.Enter:
+		return altPrefix(ev, keys.KeyEnter)
+	case keys.Tab:
+		if ev.Mod&keys.ModShift != 0 {
+			return []byte("\x1b[Z")
+		}
+		return altPrefix(ev, '\t')
+	case keys.Backspace:
+		return altPrefix(ev, keys.KeyBackspace)
+	case keys.Escape:
+		return []byte{keys.KeyEscape}
+	}
+
+	// xterm encodes modifiers as 1 plus a bit mask of shift, alt and ctrl
+	params := ""
+	if ev.Mod != 0 {
+		var bits int
+		if ev.Mod&keys.ModShift != 0 {
+			bits |= 1
+		}
+		if ev.Mod&keys.ModAlt != 0 {
+			bits |= 2
+		}
+		if ev.Mod&keys.ModCtrl != 0 {
+			bits |= 4
+		}
+		params = ";" + strconv.Itoa(bits+1)
+	}
+
+	if final, ok := csiFinals[ev.Key]; ok {
+		if params != "" {
+			params = "1" + params
+		}
+		return []byte("\x1b[" + params + string(final))
+	}
+
+	if code, ok := tildeCodes[ev.Key]; ok {
+		return []byte("\x1b[" + strconv.Itoa(code) + params + "~")
+	}
+
+	return nil
+}
+
+// altPrefix returns c, preceded by ESC when Alt is held.
+func altPrefix(ev keys.Event, c byte) []byte {
+	if ev.Mod&keys.ModAlt != 0 {
+		return []byte{keys.KeyEscape, c}
+	}
+	return []byte{c}
+}
@@ -0,0 +1,67 @@
+package pardontest
+
+import (
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+func TestScriptedKeysDecode(t *testing.T) {
+	events := []keys.Event{
+		keys.Char('a'),
+		keys.Char('日'),
+		keys.Ctrl('c'),
+		keys.Ctrl('w'),
+		keys.Alt('b'),
+		keys.Named(keys.Enter),
+		keys.Named(keys.Tab),
+		keys.Named(keys.Tab, keys.ModShift),
+		keys.Named(keys.Backspace),
+		keys.Named(keys.Backspace, keys.ModAlt),
+		keys.Named(keys.Escape),
+		keys.Named(keys.Up),
+		keys.Named(keys.Left, keys.ModCtrl),
+		keys.Named(keys.Right, keys.ModShift, keys.ModAlt),
+		keys.Named(keys.Home),
+		keys.Named(keys.End),
+		keys.Named(keys.PageUp),
+		keys.Named(keys.Delete, keys.ModCtrl),
+		keys.Named(keys.F1),
+		keys.Named(keys.F12),
+	}
+
+	term := NewTerminal(80, 24).Send(events...)
+
+	for _, want := range events {
+		got, err := tui.ReadKey(term)
+		if err != nil {
+			t.Fatalf("ReadKey() error = %v; want %v", err, want)
+		}
+		if got != want {
+			t.Errorf("ReadKey() = %v; want %v", got, want)
+		}
+	}
+
+	if _, err := tui.ReadKey(term); err != tui.ErrEndOfInput {
+		t.Errorf("ReadKey() after the script error = %v; want %v", err, tui.ErrEndOfInput)
+	}
+}
+
+func TestTypeAndPress(t *testing.T) {
+	term := NewTerminal(80, 24).Type("hé").Press(keys.Enter)
+
+	if n := term.Pending(); n != 3 {
+		t.Fatalf("Pending() = %d; want 3", n)
+	}
+
+	for _, want := range []keys.Event{keys.Char('h'), keys.Char('é'), keys.Named(keys.Enter)} {
+		if got, err := tui.ReadKey(term); err != nil || got != want {
+			t.Errorf("ReadKey() = %v, %v; want %v", got, err, want)
+		}
+	}
+
+	if term.IsRaw() {
+		t.Error("IsRaw() = true after reading keys")
+	}
+}
@@ -0,0 +1,29 @@
+package pardontest_test
+
+import (
+	"fmt"
+
+	"github.com/engmtcdrm/go-pardon"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+func Example() {
+	term := pardontest.NewTerminal(80, 24)
+	term.Press(keys.Down, keys.Down, keys.Enter)
+
+	var color int
+	err := pardon.NewSelect[int]().
+		Icon("").
+		Title("Choose a color:").
+		Options(pardon.NewOption("Red", 1), pardon.NewOption("Blue", 2), pardon.NewOption("Green", 3)).
+		Value(&color).
+		Terminal(term).
+		Ask()
+
+	fmt.Println(err, color)
+	fmt.Println(term.Screen())
+	// Output:
+	// <nil> 3
+	// Choose a color: Green
+}
@@ -0,0 +1,425 @@
+package pardontest
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// screen is an in-memory emulation of the parts of a VT100-style terminal
+// that prompts use: text, line feeds, cursor movement and erasing.
+type screen struct {
+	width, height int
+	cells         [][]string // Graphemes per cell; "" marks the right half of a wide character
+	wrapped       []bool     // Rows whose text continues on the next row
+	row, col      int
+	wrapPending   bool // The last column has been written and the next character wraps
+	cursorHidden  bool
+	savedRow      int
+	savedCol      int
+	pending       []byte // Incomplete escape sequence or UTF-8 from the last write
+}
+
+// newScreen creates a blank screen of the given size.
+func newScreen(width, height int) *screen {
+	s := &screen{width: width, height: height}
+	s.cells = make([][]string, height)
+	s.wrapped = make([]bool, height)
+	for i := range s.cells {
+		s.cells[i] = s.blankRow()
+	}
+	return s
+}
+
+// blankRow returns a row of empty cells.
+func (s *screen) blankRow() []string {
+	row := make([]string, s.width)
+	for i := range row {
+		row[i] = " "
+	}
+	return row
+}
+
+// write interprets output written to the terminal.
+func (s *screen) write(p []byte) {
+	data := append(s.pending, p...)
+	s.pending = nil
+
+	for len(data) > 0 {
+		n := s.step(data)
+		if n == 0 {
+			// Incomplete sequence, keep it for the next write
+			s.pending = append([]byte(nil), data...)
+			return
+		}
+		data = data[n:]
+	}
+}
+
+// step interprets one control character, escape sequence or run of text at
+// the start of data, returning the number of bytes consumed or zero if more
+// input is needed.
+func (s *screen) step(data []byte) int {
+	switch c := data[0]; {
+	case c == 0x1b:
+		return s.escape(data)
+	case c < 0x20 || c == 0x7f:
+		s.control(c)
+		return 1
+	}
+
+	// Text runs up to the next control character
+	end := 0
+	for end < len(data) && data[end] >= 0x20 && data[end] != 0x7f {
+		end++
+	}
+
+	if end == len(data) {
+		// Keep a character split across writes for the next one
+		for i := max(end-3, 0); i < end; i++ {
+			if utf8.RuneStart(data[i]) && !utf8.FullRune(data[i:end]) {
+				end = i
+				break
+			}
+		}
+		if end == 0 {
+			return 0
+		}
+	}
+
+	text := string(data[:end])
+	for _, g := range tui.Graphemes(text) {
+		s.put(g)
+	}
+	return end
+}
+
+// control handles a C0 control character.
+func (s *screen) control(c byte) {
+	switch c {
+	case '\r':
+		s.col = 0
+		s.wrapPending = false
+	case '\n':
+		// Output is written with the terminal in cooked mode, which turns
+		// line feeds into a carriage return and line feed
+		s.col = 0
+		s.lineFeed()
+	case '\b':
+		if s.col > 0 {
+			s.col--
+		}
+		s.wrapPending = false
+	case '\t':
+		s.col = min((s.col/8+1)*8, s.width-1)
+	}
+}
+
+// lineFeed moves the cursor down a row, scrolling at the bottom of the screen.
+func (s *screen) lineFeed() {
+	s.wrapPending = false
+	if s.row < s.height-1 {
+		s.row++
+		return
+	}
+	s.scrollUp(1)
+}
+
+// scrollUp scrolls the screen contents up by n rows.
+func (s *screen) scrollUp(n int) {
+	for range min(n, s.height) {
+		s.cells = append(s.cells[1:], s.blankRow())
+		s.wrapped = append(s.wrapped[1:], false)
+	}
+}
+
+// scrollDown scrolls the screen contents down by n rows.
+func (s *screen) scrollDown(n int) {
+	for range min(n, s.height) {
+		s.cells = append([][]string{s.blankRow()}, s.cells[:s.height-1]...)
+		s.wrapped = append([]bool{false}, s.wrapped[:s.height-1]...)
+	}
+}
+
+// put writes a grapheme at the cursor, wrapping at the right margin.
+func (s *screen) put(g string) {
+	w := tui.StringWidth(g)
+	if w == 0 {
+		// Attach stray combining characters to the previous cell
+		if s.col > 0 {
+			s.cells[s.row][s.col-1] += g
+		}
+		return
+	}
+
+	if s.wrapPending || s.col+w > s.width {
+		s.wrapped[s.row] = true
+		s.col = 0
+		s.lineFeed()
+	}
+
+	row := s.cells[s.row]
+
+	// Overwriting half of a wide character erases the other half
+	if row[s.col] == "" && s.col > 0 {
+		row[s.col-1] = " "
+	}
+	if end := s.col + w; end < s.width && row[end] == "" {
+		row[end] = " "
+	}
+
+	row[s.col] = g
+	for i := 1; i < w; i++ {
+		row[s.col+i] = ""
+	}
+
+	if s.col+w >= s.width {
+		s.col = s.width - 1
+		s.wrapPending = true
+	} else {
+		s.col += w
+	}
+}
+
+// escape interprets an escape sequence at the start of data.
+func (s *screen) escape(data []byte) int {
+	if len(data) < 2 {
+		return 0
+	}
+
+	switch data[1] {
+	case '[':
+		return s.csi(data)
+	case ']':
+		// Operating system command, terminated by BEL or ST
+		for i := 2; i < len(data); i++ {
+			if data[i] == 0x07 {
+				return i + 1
+			}
+			if data[i] == 0x1b && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return 0
+	case '7':
+		s.savedRow, s.savedCol = s.row, s.col
+	case '8':
+		s.row, s.col = s.savedRow, s.savedCol
+		s.wrapPending = false
+	}
+	return 2
+}
+
+// csi interprets a control sequence of the form "ESC [ params final".
+func (s *screen) csi(data []byte) int {
+	i := 2
+	for i < len(data) && data[i] >= 0x20 && data[i] <= 0x3f {
+		i++
+	}
+	if i >= len(data) {
+		return 0
+	}
+
+	final := data[i]
+	rawParams := string(data[2:i])
+	private := strings.HasPrefix(rawParams, "?")
+	params := strings.Split(strings.TrimPrefix(rawParams, "?"), ";")
+
+	param := func(idx, def int) int {
+		if idx >= len(params) {
+			return def
+		}
+		v, err := strconv.Atoi(params[idx])
+		if err != nil || v == 0 {
+			return def
+		}
+		return v
+	}
+
+	if private {
+		if params[0] == "25" {
+			switch final {
+			case 'h':
+				s.cursorHidden = false
+			case 'l':
+				s.cursorHidden = true
+			}
+		}
+		return i + 1
+	}
+
+	if final != 'm' {
+		s.wrapPending = false
+	}
+
+	switch final {
+	case 'A':
+		s.row = max(s.row-param(0, 1), 0)
+	case 'B':
+		s.row = min(s.row+param(0, 1), s.height-1)
+	case 'C':
+		s.col = min(s.col+param(0, 1), s.width-1)
+	case 'D':
+		s.col = max(s.col-param(0, 1), 0)
+	case 'E':
+		s.row = min(s.row+param(0, 1), s.height-1)
+		s.col = 0
+	case 'F':
+		s.row = max(s.row-param(0, 1), 0)
+		s.col = 0
+	case 'G':
+		s.col = min(param(0, 1), s.width) - 1
+	case 'H', 'f':
+		s.row = min(param(0, 1), s.height) - 1
+		s.col = min(param(1, 1), s.width) - 1
+	case 'J':
+		s.eraseScreen(param(0, 0))
+	case 'K':
+		s.eraseLine(param(0, 0))
+	case 'S':
+		s.scrollUp(param(0, 1))
+	case 'T':
+		s.scrollDown(param(0, 1))
+	case 's':
+		s.savedRow, s.savedCol = s.row, s.col
+	case 'u':
+		s.row, s.col = s.savedRow, s.savedCol
+	}
+
+	return i + 1
+}
+
+// eraseLine erases part of the cursor's row: to the end (0), to the start
+// (1) or all of it (2).
+func (s *screen) eraseLine(mode int) {
+	from, to := s.col, s.width
+	switch mode {
+	case 1:
+		from, to = 0, s.col+1
+	case 2:
+		from = 0
+	}
+
+	for i := from; i < to; i++ {
+		s.cells[s.row][i] = " "
+	}
+}
+
+// eraseScreen erases part of the screen: from the cursor to the end (0),
+// from the start to the cursor (1) or all of it (2).
+func (s *screen) eraseScreen(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for r := s.row; r < s.height; r++ {
+			if r > s.row {
+				s.cells[r] = s.blankRow()
+			}
+			s.wrapped[r] = false
+		}
+	case 1:
+		s.eraseLine(1)
+		for r := 0; r < s.row; r++ {
+			s.cells[r] = s.blankRow()
+			s.wrapped[r] = false
+		}
+	case 2, 3:
+		for r := range s.cells {
+			s.cells[r] = s.blankRow()
+			s.wrapped[r] = false
+		}
+	}
+}
+
+// resize changes the size of the screen, reflowing wrapped text to the new
+// width as most terminal emulators do. Rows scroll off the top when needed to
+// keep the cursor on screen.
+func (s *screen) resize(width, height int) {
+	// Join wrapped rows back into lines, noting where the cursor falls
+	var lines [][]string
+	var line []string
+	cursorLine, cursorCell := 0, 0
+	for r := range s.height {
+		if r == s.row {
+			cursorLine, cursorCell = len(lines), len(line)+s.col
+			if s.wrapPending {
+				cursorCell++
+			}
+		}
+		line = append(line, s.cells[r]...)
+		if !s.wrapped[r] || r == s.height-1 {
+			lines = append(lines, line)
+			line = nil
+		}
+	}
+
+	var rows [][]string
+	var wrapped []bool
+	cursorRow, cursorCol, pendingWrap := 0, 0, false
+
+	for i, line := range lines {
+		// Drop trailing blanks, but keep the cells up to the cursor
+		keep := len(line)
+		for keep > 0 && line[keep-1] == " " {
+			keep--
+		}
+		if i == cursorLine {
+			keep = max(keep, min(cursorCell, len(line)))
+		}
+
+		row := make([]string, 0, width)
+		for c := 0; c <= keep; c++ {
+			if i == cursorLine && c == cursorCell {
+				cursorRow, cursorCol = len(rows), len(row)
+				if cursorCol == width {
+					cursorCol, pendingWrap = width-1, true
+				}
+			}
+			if c == keep {
+				break
+			}
+
+			g := line[c]
+			if g == "" {
+				continue
+			}
+			w := max(tui.StringWidth(g), 1)
+			if len(row)+w > width {
+				rows, wrapped = append(rows, row), append(wrapped, true)
+				row = make([]string, 0, width)
+			}
+			row = append(row, g)
+			for range w - 1 {
+				row = append(row, "")
+			}
+		}
+		rows, wrapped = append(rows, row), append(wrapped, false)
+	}
+
+	start := max(cursorRow-height+1, 0)
+
+	s.width, s.height = width, height
+	s.cells = make([][]string, height)
+	s.wrapped = make([]bool, height)
+	for r := range height {
+		s.cells[r] = s.blankRow()
+		if start+r < len(rows) {
+			copy(s.cells[r], rows[start+r])
+			s.wrapped[r] = wrapped[start+r]
+		}
+	}
+
+	s.row, s.col = cursorRow-start, min(cursorCol, width-1)
+	s.wrapPending = pendingWrap
+}
+
+// lines returns the text of every row with trailing blanks removed.
+func (s *screen) lines() []string {
+	lines := make([]string, s.height)
+	for r, row := range s.cells {
+		lines[r] = strings.TrimRight(strings.Join(row, ""), " ")
+	}
+	return lines
+}
@@ -0,0 +1,173 @@
+package pardontest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/engmtcdrm/go-ansi"
+)
+
+func TestScreenText(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"plain", "hello", "hello"},
+		{"line feed", "one\ntwo", "one\ntwo"},
+		{"carriage return overwrites", "hello\rJ", "Jello"},
+		{"colors are ignored", ansi.Green + "ok" + ansi.Reset, "ok"},
+		{"clear line", "hello\r" + ansi.ClearLine + "hi", "hi"},
+		{"clear to end of line", "hello\r" + ansi.CursorForward(2) + ansi.ClearToEnd, "he"},
+		{"cursor up and overwrite", "one\ntwo\n" + ansi.CursorUp(2) + "ONE", "ONE\ntwo"},
+		{"cursor backward", "abc" + ansi.CursorBackward(2) + "X", "aXc"},
+		{"clear to end of screen", "one\ntwo\nthree" + ansi.CursorUp(1) + "\r" + ansi.ClearFromCursorToEndScreen, "one"},
+		{"cursor position", "one\ntwo" + ansi.CursorPosition(1, 2) + "X", "oXe\ntwo"},
+		{"wide characters", "日本\rX", "X 本"},
+		{"wraps at the right margin", "abcdefghijkl", "abcdefghij\nkl"},
+		{"exact width does not wrap early", "abcdefghij\rX", "Xbcdefghij"},
+		{"wide character wraps whole", "abcdefghi日", "abcdefghi\n日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := NewTerminal(10, 5)
+			term.Write([]byte(tt.output))
+
+			if got := term.Screen(); got != tt.want {
+				t.Errorf("Screen() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreenScrolls(t *testing.T) {
+	term := NewTerminal(10, 3)
+	term.Write([]byte("1\n2\n3\n4"))
+
+	if got, want := term.Screen(), "2\n3\n4"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+	if row, col := term.Cursor(); row != 2 || col != 1 {
+		t.Errorf("Cursor() = %d, %d; want 2, 1", row, col)
+	}
+}
+
+func TestScreenSplitWrites(t *testing.T) {
+	term := NewTerminal(10, 3)
+
+	// Escape sequences and characters split across writes
+	output := "ab" + ansi.CursorBackward(1) + "X日"
+	for i := range len(output) {
+		term.Write([]byte(output[i : i+1]))
+	}
+
+	if got, want := term.Screen(), "aX日"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestScreenCursorVisibility(t *testing.T) {
+	term := NewTerminal(10, 3)
+
+	term.Write([]byte(ansi.HideCursor))
+	if term.CursorVisible() {
+		t.Error("CursorVisible() = true after hiding the cursor")
+	}
+
+	term.Write([]byte(ansi.ShowCursor))
+	if !term.CursorVisible() {
+		t.Error("CursorVisible() = false after showing the cursor")
+	}
+}
+
+func TestTerminalOutput(t *testing.T) {
+	term := NewTerminal(10, 3)
+	term.Write([]byte("a" + ansi.ClearLine))
+
+	if got := term.Output(); !strings.HasSuffix(got, ansi.ClearLine) {
+		t.Errorf("Output() = %q; want the raw escape sequences", got)
+	}
+}
+
+func TestScreenResizeReflow(t *testing.T) {
+	term := NewTerminal(10, 5)
+	term.Write([]byte("abcdefghijkl\nxy"))
+
+	term.Resize(6, 5).Resize(20, 5)
+
+	term.Read(nil)
+	if got, want := term.Screen(), "abcdef\nghijkl\nxy"; got != want {
+		t.Errorf("Screen() after narrowing = %q; want %q", got, want)
+	}
+	if row, col := term.Cursor(); row != 2 || col != 2 {
+		t.Errorf("Cursor() after narrowing = %d, %d; want 2, 2", row, col)
+	}
+
+	term.Read(nil)
+	if got, want := term.Screen(), "abcdefghijkl\nxy"; got != want {
+		t.Errorf("Screen() after widening = %q; want %q", got, want)
+	}
+	if row, col := term.Cursor(); row != 1 || col != 2 {
+		t.Errorf("Cursor() after widening = %d, %d; want 1, 2", row, col)
+	}
+}
+
+func TestScreenResizeKeepsCursorVisible(t *testing.T) {
+	term := NewTerminal(10, 5)
+	term.Write([]byte("1\n2\n3\n4"))
+
+	term.Resize(10, 2).Read(nil)
+
+	if got, want := term.Screen(), "3\n4"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+	if w, h, _ := term.Size(); w != 10 || h != 2 {
+		t.Errorf("Size() = %d, %d; want 10, 2", w, h)
+	}
+}
+
+func TestResizeNotifiesWatchers(t *testing.T) {
+	term := NewTerminal(10, 5).Resize(20, 5)
+	ch, stop := term.WatchResize()
+	defer stop()
+
+	if n, err := term.Read(make([]byte, 8)); n != 0 || err != nil {
+		t.Fatalf("Read() = %d, %v; want 0, nil", n, err)
+	}
+
+	select {
+	case <-ch:
+	default:
+		t.Error("WatchResize() channel was not notified")
+	}
+}
+
+func TestCanonicalEcho(t *testing.T) {
+	term := NewTerminal(10, 3)
+	term.Type("ab\r")
+
+	buf := make([]byte, 8)
+	for term.Pending() > 0 {
+		term.Read(buf)
+	}
+
+	// Keys read outside raw mode are echoed, but are not output of the prompt
+	if got, want := term.Screen(), "ab"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+	if row, col := term.Cursor(); row != 1 || col != 0 {
+		t.Errorf("Cursor() = %d, %d; want 1, 0", row, col)
+	}
+	if term.Output() != "" {
+		t.Errorf("Output() = %q; want nothing", term.Output())
+	}
+
+	restore, _ := term.MakeRaw()
+	defer restore()
+	term.Type("c")
+	term.Read(buf)
+	if got, want := term.Screen(), "ab"; got != want {
+		t.Errorf("Screen() after raw read = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,287 @@
+// Package pardontest provides a virtual terminal for testing prompts without
+// a TTY. Keystrokes are scripted before asking a prompt, and what the prompt
+// drew can be inspected afterwards as it would appear on screen.
+//
+//	term := pardontest.NewTerminal(80, 24)
+//	term.Press(keys.Down, keys.Down, keys.Enter)
+//
+//	err := pardon.NewSelect[int]().
+//		Title("Choose a color:").
+//		Options(pardon.NewOption("Red", 1), pardon.NewOption("Blue", 2), pardon.NewOption("Green", 3)).
+//		Value(&color).
+//		Terminal(term).
+//		Ask()
+//
+//	term.Contains("Choose a color: Green") // true
+package pardontest
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// Terminal is an in-memory terminal implementing pardon.Terminal. It feeds
+// scripted keystrokes to prompts one per read and emulates the screen they
+// render to. A Terminal is safe for use by multiple goroutines.
+type Terminal struct {
+	mu        sync.Mutex
+	script    []step
+	screen    *screen
+	output    strings.Builder
+	raw       bool
+	suspended int
+	watchers  map[chan struct{}]bool
+}
+
+// step is a single scripted keystroke or window resize.
+type step struct {
+	input         []byte
+	resize        bool
+	width, height int
+}
+
+// NewTerminal creates a virtual terminal with a blank screen of the given size.
+func NewTerminal(width, height int) *Terminal {
+	return &Terminal{screen: newScreen(width, height)}
+}
+
+// Type queues each character of s as a separate keystroke.
+func (t *Terminal) Type(s string) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, r := range s {
+		t.script = append(t.script, step{input: []byte(string(r))})
+	}
+	return t
+}
+
+// Press queues presses of named keys such as keys.Down or keys.Enter.
+func (t *Terminal) Press(ks ...keys.Key) *Terminal {
+	evs := make([]keys.Event, len(ks))
+	for i, k := range ks {
+		evs[i] = keys.Named(k)
+	}
+	return t.Send(evs...)
+}
+
+// Send queues arbitrary key events, such as keys.Ctrl('c') or
+// keys.Named(keys.Left, keys.ModCtrl).
+func (t *Terminal) Send(evs ...keys.Event) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, ev := range evs {
+		t.script = append(t.script, step{input: encode(ev)})
+	}
+	return t
+}
+
+// SendRaw queues raw input bytes delivered in a single read, for example to
+// simulate pasted text.
+func (t *Terminal) SendRaw(b []byte) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.script = append(t.script, step{input: append([]byte(nil), b...)})
+	return t
+}
+
+// Resize queues a change of the window size. When the script reaches it,
+// the screen is resized, reflowing its contents, and the prompt is notified.
+func (t *Terminal) Resize(width, height int) *Terminal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.script = append(t.script, step{resize: true, width: width, height: height})
+	return t
+}
+
+// Read delivers the next scripted keystroke, or io.EOF once the script is
+// exhausted. A scripted resize is applied and reported as a read of no bytes.
+// Outside raw mode, keystrokes are echoed to the screen as a terminal in
+// canonical mode does.
+func (t *Terminal) Read(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.script) == 0 {
+		return 0, io.EOF
+	}
+
+	next := &t.script[0]
+	if next.resize {
+		t.script = t.script[1:]
+		t.screen.resize(next.width, next.height)
+		for ch := range t.watchers {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+		return 0, nil
+	}
+
+	n := copy(p, next.input)
+	if n < len(next.input) {
+		next.input = next.input[n:]
+	} else {
+		t.script = t.script[1:]
+	}
+
+	if !t.raw {
+		t.screen.write(bytes.ReplaceAll(p[:n], []byte("\r"), []byte("\n")))
+	}
+	return n, nil
+}
+
+// ReadsWholeKeys reports that every Read ends on a whole key, so a scripted
+// Escape is read as soon as it is reached rather than after the wait used to
+// tell it apart from the start of the next key.
+func (t *Terminal) ReadsWholeKeys() bool {
+	return true
+}
+
+// WatchResize returns a channel notified when a scripted resize is reached.
+func (t *Terminal) WatchResize() (<-chan struct{}, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	if t.watchers == nil {
+		t.watchers = make(map[chan struct{}]bool)
+	}
+	t.watchers[ch] = true
+
+	return ch, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.watchers, ch)
+	}
+}
+
+// Write renders output on the emulated screen.
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.output.Write(p)
+	t.screen.write(p)
+	return len(p), nil
+}
+
+// MakeRaw records that the terminal is in raw mode until restored.
+func (t *Terminal) MakeRaw() (func() error, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.raw = true
+
+	return func() error {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.raw = false
+		return nil
+	}, nil
+}
+
+// Suspend records a suspend of the process, as requested by Ctrl-Z, and
+// returns immediately as if the shell had resumed it.
+func (t *Terminal) Suspend() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.suspended++
+	return nil
+}
+
+// Size returns the dimensions of the emulated screen.
+func (t *Terminal) Size() (int, int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.screen.width, t.screen.height, nil
+}
+
+// Pending returns the number of scripted steps not yet read.
+func (t *Terminal) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.script)
+}
+
+// Output returns everything written to the terminal, including escape sequences.
+func (t *Terminal) Output() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.output.String()
+}
+
+// Lines returns the text of each row of the screen with trailing blanks removed.
+func (t *Terminal) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.screen.lines()
+}
+
+// Screen returns the visible text of the screen, one row per line, without
+// trailing blank rows.
+func (t *Terminal) Screen() string {
+	lines := t.Lines()
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Contains reports whether any row of the screen contains s.
+func (t *Terminal) Contains(s string) bool {
+	for _, line := range t.Lines() {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cursor returns the zero-based row and column of the cursor.
+func (t *Terminal) Cursor() (row, col int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.screen.row, t.screen.col
+}
+
+// CursorVisible reports whether the cursor is shown.
+func (t *Terminal) CursorVisible() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return !t.screen.cursorHidden
+}
+
+// IsRaw reports whether the terminal is currently in raw mode, which should
+// never be the case once a prompt has returned.
+func (t *Terminal) IsRaw() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.raw
+}
+
+// Suspended returns the number of times a prompt suspended the process.
+func (t *Terminal) Suspended() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.suspended
+}
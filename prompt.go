@@ -1,7 +1,15 @@
 package pardon
 
+import "context"
+
 // Prompt is the interface implemented by all prompt types.
 type Prompt interface {
 	// Ask displays the prompt and waits for user input.
 	Ask() error
 }
+
+// contextAsker is implemented by prompts that can be cancelled through a
+// context. All of the package's prompts implement it.
+type contextAsker interface {
+	AskContext(ctx context.Context) error
+}
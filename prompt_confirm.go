@@ -1,7 +1,10 @@
 package pardon
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/engmtcdrm/go-pardon/keys"
 	"github.com/engmtcdrm/go-pardon/tui"
@@ -9,6 +12,9 @@ import (
 
 // Confirm represents a yes/no confirmation prompt for user decisions.
 type Confirm struct {
+	name     string
+	session  *Session
+	term     tui.Terminal
 	icon     eval[string]
 	title    eval[string]
 	confirm  string
@@ -19,12 +25,49 @@ type Confirm struct {
 
 // NewConfirm creates a new Confirm prompt instance.
 func NewConfirm() *Confirm {
-	return &Confirm{
-		icon:    eval[string]{val: Icons.QuestionMark, fn: nil, defaultFn: defaultFuncs.iconFn},
-		title:   eval[string]{val: "", fn: nil, defaultFn: defaultFuncs.titleFn},
+	c := &Confirm{
+		icon:    eval[string]{val: Icons.QuestionMark, fn: nil},
+		title:   eval[string]{val: "", fn: nil},
 		confirm: "Y",
 		deny:    "N",
 	}
+	c.setSession(defaultSession)
+	return c
+}
+
+// Terminal sets the terminal the confirmation prompt reads from and renders to.
+func (c *Confirm) Terminal(t Terminal) *Confirm {
+	c.setTerminal(t)
+	return c
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (c *Confirm) setTerminal(t Terminal) {
+	if t != nil {
+		c.term = t
+	}
+}
+
+// Session makes the confirmation prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (c *Confirm) Session(s *Session) *Confirm {
+	c.setSession(s)
+	return c
+}
+
+// setSession moves the prompt to s.
+func (c *Confirm) setSession(s *Session) {
+	d := s.defaults()
+	c.session = s
+	c.term = s.Terminal()
+	c.icon.defaultFn = d.iconFn
+	c.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the confirmation in an AnswerSource.
+func (c *Confirm) Name(name string) *Confirm {
+	c.name = name
+	return c
 }
 
 // Title sets a static title for the confirmation prompt.
@@ -65,27 +108,86 @@ func (c *Confirm) AnswerFunc(fn func(string) string) *Confirm {
 	return c
 }
 
+// errYesNo is shown when a line-based confirmation isn't yes or no.
+var errYesNo = errors.New("answer y, yes, n or no")
+
+// askLines reads the answer a line at a time for non-interactive terminals
+// and accessible mode. An empty line keeps the current value.
+func (c *Confirm) askLines(ctx context.Context, question string) error {
+	lines := newLineAsker(c.term, c.session)
+	for {
+		text, err := lines.read(ctx, question)
+		if err != nil {
+			return err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(text)) {
+		case "":
+		case "y", "yes":
+			*c.value = true
+		case "n", "no":
+			*c.value = false
+		default:
+			lines.reject(text, errYesNo)
+			continue
+		}
+
+		answer, choice := c.deny, "no"
+		if *c.value {
+			answer, choice = c.confirm, "yes"
+		}
+		lines.accept(text, c.setAnswerFunc(answer), choice)
+		return nil
+	}
+}
+
 // formatFinalOutput formats the final confirmation display after user selection.
 func (c *Confirm) formatFinalOutput(question string, answer string) string {
-	return tui.RenderFormattedOutput(question, c.setAnswerFunc(answer))
+	return question + " " + c.setAnswerFunc(answer)
 }
 
 // setAnswerFunc configures the answer transformation priority:
-// prompt-specific, global default, or the string itself.
+// prompt-specific, session default, or the string itself.
 func (c *Confirm) setAnswerFunc(s string) string {
 	if c.answerFn != nil {
 		return c.answerFn(s)
 	}
 
-	if defaultFuncs.answerFn != nil {
-		return defaultFuncs.answerFn(s)
+	if fn := c.session.defaults().answerFn; fn != nil {
+		return fn(s)
 	}
 
 	return s
 }
 
+// promptName returns the name of the confirmation prompt.
+func (c *Confirm) promptName() string {
+	return c.name
+}
+
+// answer sets the value from a scripted boolean or yes/no answer.
+func (c *Confirm) answer(v any) error {
+	if c.value == nil {
+		return ErrNoValue
+	}
+
+	b, err := answerBool(v)
+	if err != nil {
+		return err
+	}
+
+	*c.value = b
+	return nil
+}
+
 // Ask displays the confirmation prompt.
 func (c *Confirm) Ask() error {
+	return c.AskContext(context.Background())
+}
+
+// AskContext displays the confirmation prompt, removing it and returning the
+// context's error if ctx is done before an answer is given.
+func (c *Confirm) AskContext(ctx context.Context) error {
 	if c.title.val == "" && c.title.fn == nil {
 		return ErrNoTitle
 	}
@@ -102,31 +204,51 @@ func (c *Confirm) Ask() error {
 	question := fmt.Sprintf("%s%s", c.icon.Get(), c.title.Get())
 	question_opt := fmt.Sprintf("%s %s ", question, options)
 
+	if !tui.IsInteractive(c.term) || c.session.Accessible() {
+		return c.askLines(ctx, question_opt)
+	}
+
+	defer tui.Guard(c.term)()
+	reader := tui.NewKeyReader(c.term)
+	defer reader.Close()
+
 	// Display the confirmation prompt
-	fmt.Print(question_opt)
+	screen := tui.NewRenderer(c.term)
+	screen.Draw([]string{question_opt})
 
 	// Capture user input
 	for {
-		keyCode := tui.GetInput()
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				screen.Erase()
+			}
+			return err
+		}
 
-		switch keyCode {
-		case keys.KeyYesUpper, keys.KeyYes:
+		switch {
+		case ev.Key == keys.Resize:
+			screen.Draw([]string{question_opt})
+		case ev.Key == keys.Resume:
+			screen.Reset()
+			screen.Draw([]string{question_opt})
+		case ev == keys.Char(rune(keys.KeyYesUpper)), ev == keys.Char(rune(keys.KeyYes)):
 			*c.value = true
-			fmt.Print(c.formatFinalOutput(question, c.confirm))
+			screen.Finish(c.formatFinalOutput(question, c.confirm))
 			return nil
-		case keys.KeyNoUpper, keys.KeyNo:
+		case ev == keys.Char(rune(keys.KeyNoUpper)), ev == keys.Char(rune(keys.KeyNo)):
 			*c.value = false
-			fmt.Print(c.formatFinalOutput(question, c.deny))
+			screen.Finish(c.formatFinalOutput(question, c.deny))
 			return nil
-		case keys.KeyEnter, keys.KeyCarriageReturn:
+		case ev.Key == keys.Enter:
 			if *c.value {
-				fmt.Print(c.formatFinalOutput(question, c.confirm))
+				screen.Finish(c.formatFinalOutput(question, c.confirm))
 			} else {
-				fmt.Print(c.formatFinalOutput(question, c.deny))
+				screen.Finish(c.formatFinalOutput(question, c.deny))
 			}
 			return nil
-		case keys.KeyCtrlC, keys.KeyEscape:
-			fmt.Println()
+		case ev == keys.Ctrl('c'), ev.Key == keys.Escape:
+			screen.Finish(question_opt)
 			return ErrUserAborted
 		}
 	}
@@ -2,6 +2,9 @@ package pardon
 
 import (
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
 )
 
 func TestConfirmCreation(t *testing.T) {
@@ -82,3 +85,42 @@ func TestConfirmWithAnswerFunc(t *testing.T) {
 		t.Error("Confirm with answer function returned nil")
 	}
 }
+
+func TestConfirmScreen(t *testing.T) {
+	result := false
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("y")
+
+	err := NewConfirm().Icon("").Title("Continue?").Value(&result).Terminal(term).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if !result {
+		t.Error("Ask() value = false; want true")
+	}
+	if got, want := term.Screen(), "Continue? Y"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestConfirmAccessible(t *testing.T) {
+	result := false
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("y").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	err := NewConfirm().Icon("").Title("Continue?").Value(&result).Session(session).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if !result {
+		t.Error("Ask() value = false; want true")
+	}
+	if got, want := term.Screen(), "Continue? [y/N] y\nSelected: yes"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,653 @@
+package pardon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Default layouts of dates typed into a Date prompt.
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+// dateFocus is the part of a Date prompt that keys move.
+type dateFocus int
+
+const (
+	focusCalendar dateFocus = iota
+	focusHour
+	focusMinute
+)
+
+// now returns the current time, used when a Date prompt has no value yet.
+var now = time.Now
+
+// Date represents a date prompt showing a calendar of the month. Arrow keys
+// move by days and weeks, PgUp and PgDn by months, and a date can also be
+// typed in the prompt's layout.
+type Date struct {
+	name      string
+	session   *Session
+	term      tui.Terminal
+	icon      eval[string]
+	title     eval[string]
+	layout    string
+	withTime  bool
+	weekStart time.Weekday
+	min, max  *time.Time
+	answerFn  func(string) string
+	selectFn  func(string) string
+	value     *time.Time
+	screen    *tui.Renderer
+	current   time.Time // Date shown on the calendar
+	text      []rune    // Date being typed, if any
+	focus     dateFocus
+	err       string
+}
+
+// NewDate creates a new Date prompt instance choosing a day.
+func NewDate() *Date {
+	d := &Date{
+		icon:   eval[string]{val: Icons.QuestionMark},
+		title:  eval[string]{val: ""},
+		layout: dateLayout,
+	}
+	d.setSession(defaultSession)
+	return d
+}
+
+// NewDateTime creates a new Date prompt instance choosing a day and a time
+// of day. Tab moves between the calendar, hours and minutes.
+func NewDateTime() *Date {
+	d := NewDate()
+	d.layout = dateTimeLayout
+	d.withTime = true
+	return d
+}
+
+// Terminal sets the terminal the date prompt reads from and renders to.
+func (d *Date) Terminal(t Terminal) *Date {
+	d.setTerminal(t)
+	return d
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (d *Date) setTerminal(t Terminal) {
+	if t != nil {
+		d.term = t
+	}
+}
+
+// Session makes the date prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (d *Date) Session(s *Session) *Date {
+	d.setSession(s)
+	return d
+}
+
+// setSession moves the prompt to s.
+func (d *Date) setSession(s *Session) {
+	defaults := s.defaults()
+	d.session = s
+	d.term = s.Terminal()
+	d.icon.defaultFn = defaults.iconFn
+	d.title.defaultFn = defaults.titleFn
+}
+
+// Name sets the name used to look up the date in an AnswerSource.
+func (d *Date) Name(name string) *Date {
+	d.name = name
+	return d
+}
+
+// Title sets the prompt text.
+func (d *Date) Title(title string) *Date {
+	d.title.val = title
+	d.title.fn = nil
+	return d
+}
+
+// TitleFunc sets a dynamic title function.
+func (d *Date) TitleFunc(fn func(string) string) *Date {
+	d.title.fn = fn
+	return d
+}
+
+// Icon sets the prompt icon.
+func (d *Date) Icon(s string) *Date {
+	d.icon.val = s
+	d.icon.fn = nil
+	return d
+}
+
+// IconFunc sets a dynamic icon function.
+func (d *Date) IconFunc(fn func(string) string) *Date {
+	d.icon.fn = fn
+	return d
+}
+
+// Value sets the date to fill in, which also holds the default. A zero
+// date starts the calendar on today.
+func (d *Date) Value(value *time.Time) *Date {
+	d.value = value
+	return d
+}
+
+// Min sets the earliest date accepted. It can't be after Max.
+func (d *Date) Min(t time.Time) *Date {
+	d.min = &t
+	return d
+}
+
+// Max sets the latest date accepted.
+func (d *Date) Max(t time.Time) *Date {
+	d.max = &t
+	return d
+}
+
+// Layout sets the time.Parse layout dates are typed and shown in. It
+// defaults to 2006-01-02, or 2006-01-02 15:04 for NewDateTime.
+func (d *Date) Layout(layout string) *Date {
+	if layout != "" {
+		d.layout = layout
+	}
+	return d
+}
+
+// WeekStart sets the first day of the week shown on the calendar, Sunday
+// by default.
+func (d *Date) WeekStart(day time.Weekday) *Date {
+	d.weekStart = day % 7
+	return d
+}
+
+// AnswerFunc sets a function to transform the final answer.
+func (d *Date) AnswerFunc(fn func(string) string) *Date {
+	d.answerFn = fn
+	return d
+}
+
+// SelectFunc sets a function to highlight the chosen day on the calendar.
+func (d *Date) SelectFunc(fn func(string) string) *Date {
+	d.selectFn = fn
+	return d
+}
+
+// getSelectFunc returns s highlighted as the chosen day or time, in
+// reverse video when no select function has been configured.
+func (d *Date) getSelectFunc(s string) string {
+	if d.selectFn != nil {
+		return d.selectFn(s)
+	}
+
+	if fn := d.session.defaults().selectFn; fn != nil {
+		return fn(s)
+	}
+
+	return ansi.Reverse + s + ansi.ResetReverse
+}
+
+// getAnswerFunc returns the formatted text for the final answer display.
+func (d *Date) getAnswerFunc(answer string) string {
+	if d.answerFn != nil {
+		return d.answerFn(answer)
+	}
+
+	if fn := d.session.defaults().answerFn; fn != nil {
+		return fn(answer)
+	}
+
+	return answer
+}
+
+// location returns the time zone dates are chosen in, that of the value
+// unless it is unset.
+func (d *Date) location() *time.Location {
+	if d.value == nil || d.value.IsZero() {
+		return time.Local
+	}
+	return d.value.Location()
+}
+
+// normalize drops the parts of t the prompt does not choose: the time of
+// day for dates and the seconds for dates with times.
+func (d *Date) normalize(t time.Time) time.Time {
+	y, m, day := t.Date()
+	if !d.withTime {
+		return time.Date(y, m, day, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(y, m, day, t.Hour(), t.Minute(), 0, 0, t.Location())
+}
+
+// bound returns t compared against the bounds. For dates this is the day
+// of t wherever it was given, so a bound of midnight UTC is that same day
+// in any time zone.
+func (d *Date) bound(t time.Time) time.Time {
+	if d.withTime {
+		return t
+	}
+	y, m, day := t.Date()
+	return time.Date(y, m, day, 0, 0, 0, 0, d.location())
+}
+
+// tooEarly and tooLate report whether t is outside the bounds.
+func (d *Date) tooEarly(t time.Time) bool { return d.min != nil && t.Before(d.bound(*d.min)) }
+func (d *Date) tooLate(t time.Time) bool  { return d.max != nil && t.After(d.bound(*d.max)) }
+
+// emptyRange reports whether the minimum is after the maximum, leaving no
+// date to choose.
+func (d *Date) emptyRange() bool {
+	return d.min != nil && d.max != nil && d.bound(*d.min).After(d.bound(*d.max))
+}
+
+// checkRange returns an error if t is outside the bounds.
+func (d *Date) checkRange(t time.Time) error {
+	early, late := d.tooEarly(d.bound(t)), d.tooLate(d.bound(t))
+	switch {
+	case (early || late) && d.min != nil && d.max != nil:
+		return fmt.Errorf("enter a date from %s to %s", d.format(*d.min), d.format(*d.max))
+	case early:
+		return fmt.Errorf("enter a date no earlier than %s", d.format(*d.min))
+	case late:
+		return fmt.Errorf("enter a date no later than %s", d.format(*d.max))
+	}
+	return nil
+}
+
+// clamp moves t within the bounds.
+func (d *Date) clamp(t time.Time) time.Time {
+	if d.tooEarly(d.bound(t)) {
+		// Round up to the next minute a minimum with seconds
+		limit := d.limit(*d.min, t.Location())
+		if d.withTime && limit.Before(*d.min) {
+			limit = limit.Add(time.Minute)
+		}
+		return limit
+	}
+	if d.tooLate(d.bound(t)) {
+		return d.limit(*d.max, t.Location())
+	}
+	return t
+}
+
+// limit returns bound b as a date the prompt can choose in loc.
+func (d *Date) limit(b time.Time, loc *time.Location) time.Time {
+	if !d.withTime {
+		y, m, day := b.Date()
+		return time.Date(y, m, day, 0, 0, 0, 0, loc)
+	}
+	return d.normalize(b.In(loc))
+}
+
+// format formats t in the prompt's layout.
+func (d *Date) format(t time.Time) string {
+	return d.bound(t).In(d.location()).Format(d.layout)
+}
+
+// parse reads s in the prompt's layout, checking it is within range.
+func (d *Date) parse(s string) (time.Time, error) {
+	t, err := time.ParseInLocation(d.layout, strings.TrimSpace(s), d.location())
+	if err != nil {
+		if d.withTime {
+			return time.Time{}, fmt.Errorf("enter a date and time like %s", layoutHint(d.layout))
+		}
+		return time.Time{}, fmt.Errorf("enter a date like %s", layoutHint(d.layout))
+	}
+
+	t = d.normalize(t)
+	if err := d.checkRange(t); err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// layoutHint describes a time.Parse layout the way it is usually written,
+// such as YYYY-MM-DD for 2006-01-02.
+func layoutHint(layout string) string {
+	return strings.NewReplacer(
+		"2006", "YYYY", "01", "MM", "02", "DD",
+		"15", "hh", "03", "hh", "04", "mm", "05", "ss",
+	).Replace(layout)
+}
+
+// addMonths moves t by n months, keeping the day within the month reached
+// rather than overflowing into the next.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, day := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return first.AddDate(0, 0, min(day, daysIn(first))-1)
+}
+
+// daysIn returns the number of days in the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// move applies a navigation key to the current date. It returns false if
+// the key doesn't move the date.
+func (d *Date) move(ev keys.Event) bool {
+	t := d.current
+	switch d.focus {
+	case focusCalendar:
+		switch ev.Key {
+		case keys.Left:
+			t = t.AddDate(0, 0, -1)
+		case keys.Right:
+			t = t.AddDate(0, 0, 1)
+		case keys.Up:
+			t = t.AddDate(0, 0, -7)
+		case keys.Down:
+			t = t.AddDate(0, 0, 7)
+		case keys.PageUp:
+			t = addMonths(t, -1)
+		case keys.PageDown:
+			t = addMonths(t, 1)
+		case keys.Home:
+			t = t.AddDate(0, 0, 1-t.Day())
+		case keys.End:
+			t = t.AddDate(0, 0, daysIn(t)-t.Day())
+		default:
+			return false
+		}
+	default:
+		unit := time.Hour
+		if d.focus == focusMinute {
+			unit = time.Minute
+		}
+		switch ev.Key {
+		case keys.Left, keys.Right:
+			d.focus = focusHour + focusMinute - d.focus
+			return true
+		case keys.Up:
+			t = d.addClock(t, unit)
+		case keys.Down:
+			t = d.addClock(t, -unit)
+		default:
+			return false
+		}
+	}
+
+	d.current = d.clamp(t)
+	return true
+}
+
+// addClock moves the time of day of t by delta, wrapping around within
+// the same day.
+func (d *Date) addClock(t time.Time, delta time.Duration) time.Time {
+	y, m, day := t.Date()
+	mins := (t.Hour()*60 + t.Minute() + int(delta/time.Minute)) % (24 * 60)
+	if mins < 0 {
+		mins += 24 * 60
+	}
+	return time.Date(y, m, day, mins/60, mins%60, 0, 0, t.Location())
+}
+
+// promptName returns the name of the date prompt.
+func (d *Date) promptName() string {
+	return d.name
+}
+
+// answer sets the value from a scripted time.Time or a date in the
+// prompt's layout after checking it.
+func (d *Date) answer(v any) error {
+	if d.value == nil {
+		return ErrNoValue
+	}
+
+	if d.emptyRange() {
+		return ErrInvalidRange
+	}
+
+	if t, ok := v.(time.Time); ok {
+		t = d.normalize(t)
+		if err := d.checkRange(t); err != nil {
+			return err
+		}
+		*d.value = t
+		return nil
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	t, err := d.parse(s)
+	if err != nil {
+		return err
+	}
+
+	*d.value = t
+	return nil
+}
+
+// Ask displays the date prompt and waits for a date to be chosen.
+func (d *Date) Ask() error {
+	return d.AskContext(context.Background())
+}
+
+// AskContext displays the date prompt until a date is chosen or ctx is
+// done, erasing the prompt and returning an error wrapping ctx.Err() in the
+// latter case.
+func (d *Date) AskContext(ctx context.Context) error {
+	if d.title.val == "" && d.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if d.value == nil {
+		return ErrNoValue
+	}
+
+	if d.emptyRange() {
+		return ErrInvalidRange
+	}
+
+	start := *d.value
+	if start.IsZero() {
+		start = now().In(time.Local)
+	}
+	d.current = d.clamp(d.normalize(start))
+	d.text = d.text[:0]
+	d.focus = focusCalendar
+	d.err = ""
+
+	if !tui.IsInteractive(d.term) || d.session.Accessible() {
+		return d.askLines(ctx)
+	}
+
+	defer tui.Guard(d.term)()
+	defer func() {
+		fmt.Fprint(d.term, ansi.ShowCursor)
+	}()
+	reader := tui.NewKeyReader(d.term)
+	defer reader.Close()
+
+	d.screen = tui.NewRenderer(d.term)
+	fmt.Fprint(d.term, ansi.HideCursor)
+	d.render()
+
+	for {
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				d.screen.Erase()
+			}
+			return err
+		}
+
+		if d.move(ev) {
+			d.text = d.text[:0]
+			d.err = ""
+			d.render()
+			continue
+		}
+
+		switch {
+		case ev.Key == keys.Resize:
+		case ev.Key == keys.Resume:
+			d.screen.Reset()
+			fmt.Fprint(d.term, ansi.HideCursor)
+		case ev == keys.Ctrl('c'):
+			return ErrUserAborted
+		case ev.Key == keys.Enter:
+			t := d.current
+			if len(d.text) > 0 {
+				if t, err = d.parse(string(d.text)); err != nil {
+					d.err = err.Error()
+					break
+				}
+			} else if err := d.checkRange(t); err != nil {
+				d.err = err.Error()
+				break
+			}
+
+			*d.value = t
+			d.screen.Finish(d.icon.Get() + d.title.Get() + " " + d.getAnswerFunc(d.format(t)))
+			return nil
+		case ev.Key == keys.Tab && d.withTime:
+			d.focus = (d.focus + 1) % (focusMinute + 1)
+		case ev.Key == keys.Backspace:
+			if len(d.text) == 0 {
+				continue
+			}
+			d.text = d.text[:len(d.text)-1]
+			d.typed()
+		case ev.Key == keys.Escape:
+			if len(d.text) == 0 {
+				continue
+			}
+			d.text = d.text[:0]
+			d.err = ""
+		case ev.IsPrintable():
+			d.text = append(d.text, ev.Rune)
+			d.typed()
+		default:
+			continue
+		}
+
+		d.render()
+	}
+}
+
+// typed moves the calendar to the date being typed once it is complete.
+func (d *Date) typed() {
+	d.err = ""
+	if t, err := time.ParseInLocation(d.layout, string(d.text), d.location()); err == nil {
+		d.current = d.normalize(t)
+	}
+}
+
+// askLines reads a typed date a line at a time for non-interactive
+// terminals and accessible mode. An empty line keeps the default.
+func (d *Date) askLines(ctx context.Context) error {
+	fmt.Fprintf(d.term, "%s%s\n", d.icon.Get(), d.title.Get())
+
+	what := "date"
+	if d.withTime {
+		what = "date and time"
+	}
+	prompt := fmt.Sprintf("Enter a %s (%s) [%s]: ", what, layoutHint(d.layout), d.format(d.current))
+
+	lines := newLineAsker(d.term, d.session)
+	for {
+		text, err := lines.read(ctx, prompt)
+		if err != nil {
+			return err
+		}
+
+		t := d.current
+		if strings.TrimSpace(text) != "" {
+			t, err = d.parse(text)
+		} else {
+			err = d.checkRange(t)
+		}
+		if err != nil {
+			lines.reject(text, err)
+			continue
+		}
+
+		*d.value = t
+		lines.accept(text, d.getAnswerFunc(d.format(t)), d.format(t))
+		return nil
+	}
+}
+
+// render draws the prompt line, the calendar of the current month and,
+// for dates with times, the time of day.
+func (d *Date) render() {
+	shown := d.format(d.current)
+	if len(d.text) > 0 {
+		shown = string(d.text)
+	}
+
+	lines := []string{d.icon.Get() + d.title.Get() + " " + shown}
+	lines = append(lines, d.calendar()...)
+
+	if d.withTime {
+		hour, minute := fmt.Sprintf("%02d", d.current.Hour()), fmt.Sprintf("%02d", d.current.Minute())
+		switch d.focus {
+		case focusHour:
+			hour = d.getSelectFunc(hour)
+		case focusMinute:
+			minute = d.getSelectFunc(minute)
+		}
+		lines = append(lines, "Time: "+hour+":"+minute)
+	}
+
+	if d.err != "" {
+		lines = append(lines, tui.FormatError(d.err))
+	}
+
+	d.screen.Draw(lines)
+}
+
+// calendar returns the lines of the month of the current date: the month
+// and year, the days of the week, then a line per week. The current day is
+// highlighted and days wholly out of range are dimmed.
+func (d *Date) calendar() []string {
+	const width = 7*3 - 1
+
+	month := d.current.Format("January 2006")
+	lines := []string{strings.Repeat(" ", (width-len(month))/2) + month}
+
+	names := make([]string, 7)
+	for i := range names {
+		names[i] = time.Weekday((int(d.weekStart) + i) % 7).String()[:2]
+	}
+	lines = append(lines, strings.Join(names, " "))
+
+	y, m, _ := d.current.Date()
+	first := time.Date(y, m, 1, 0, 0, 0, 0, d.current.Location())
+	offset := (int(first.Weekday()) - int(d.weekStart) + 7) % 7
+
+	week := make([]string, offset, 7)
+	for i := range week {
+		week[i] = "  "
+	}
+
+	for day := 1; day <= daysIn(first); day++ {
+		cell := fmt.Sprintf("%2d", day)
+		date := first.AddDate(0, 0, day-1)
+		switch {
+		case day == d.current.Day():
+			cell = d.getSelectFunc(cell)
+		case d.tooEarly(date.AddDate(0, 0, 1).Add(-time.Nanosecond)) || d.tooLate(date):
+			cell = ansi.Dim + cell + ansi.ResetIntensity
+		}
+
+		week = append(week, cell)
+		if len(week) == 7 || day == daysIn(first) {
+			lines = append(lines, strings.Join(week, " "))
+			week = week[:0]
+		}
+	}
+
+	return lines
+}
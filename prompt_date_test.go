@@ -0,0 +1,263 @@
+package pardon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+// day returns midnight UTC on the given date.
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDateCreation(t *testing.T) {
+	date := NewDate()
+
+	if date.layout != "2006-01-02" || date.withTime {
+		t.Errorf("NewDate() layout = %q, withTime = %v", date.layout, date.withTime)
+	}
+	if dt := NewDateTime(); dt.layout != "2006-01-02 15:04" || !dt.withTime {
+		t.Errorf("NewDateTime() layout = %q, withTime = %v", dt.layout, dt.withTime)
+	}
+
+	var result time.Time
+	if err := date.Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewDate().Title("When?").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+
+	late := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	early := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := NewDate().Title("When?").Min(late).Max(early).Value(&result).Ask(); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrInvalidRange)
+	}
+	if err := NewDate().Min(late).Max(early).Value(&result).answer(late); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("answer() error = %v; want %v", err, ErrInvalidRange)
+	}
+	if err := NewDate().Min(late.Add(time.Hour)).Max(late).Value(&result).answer(late); err != nil {
+		t.Errorf("answer() with bounds on the same day error = %v", err)
+	}
+}
+
+func TestDateNavigation(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		date  func(*Date) *Date
+		keys  []keys.Key
+		want  time.Time
+	}{
+		{"next day", day(2026, 10, 18), nil, []keys.Key{keys.Right}, day(2026, 10, 19)},
+		{"previous day", day(2026, 10, 1), nil, []keys.Key{keys.Left}, day(2026, 9, 30)},
+		{"next week", day(2026, 10, 18), nil, []keys.Key{keys.Down}, day(2026, 10, 25)},
+		{"previous week", day(2026, 10, 18), nil, []keys.Key{keys.Up, keys.Up}, day(2026, 10, 4)},
+		{"next month", day(2026, 10, 18), nil, []keys.Key{keys.PageDown}, day(2026, 11, 18)},
+		{"short month", day(2026, 1, 31), nil, []keys.Key{keys.PageDown}, day(2026, 2, 28)},
+		{"previous year", day(2026, 1, 15), nil, []keys.Key{keys.PageUp}, day(2025, 12, 15)},
+		{"end of month", day(2026, 2, 3), nil, []keys.Key{keys.End}, day(2026, 2, 28)},
+		{"start of month", day(2026, 2, 3), nil, []keys.Key{keys.Home}, day(2026, 2, 1)},
+		{"stops at max", day(2026, 10, 18), func(d *Date) *Date { return d.Max(day(2026, 10, 20)) },
+			[]keys.Key{keys.Down}, day(2026, 10, 20)},
+		{"stops at min", day(2026, 10, 18), func(d *Date) *Date { return d.Min(day(2026, 10, 1)) },
+			[]keys.Key{keys.PageUp}, day(2026, 10, 1)},
+		{"drops time of day", time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC), nil, nil, day(2026, 10, 18)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.start
+			term := pardontest.NewTerminal(40, 12)
+			term.Press(tt.keys...).Press(keys.Enter)
+
+			date := NewDate().Title("When?").Value(&result).Terminal(term)
+			if tt.date != nil {
+				date = tt.date(date)
+			}
+			if err := date.Ask(); err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if !result.Equal(tt.want) {
+				t.Errorf("Ask() value = %v; want %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateScreen(t *testing.T) {
+	result := day(2026, 10, 18)
+	term := pardontest.NewTerminal(40, 12)
+	term.Press(keys.Right).Send(keys.Ctrl('c'))
+
+	err := NewDate().Icon("").Title("When?").Value(&result).Terminal(term).Ask()
+	if !errors.Is(err, ErrUserAborted) {
+		t.Fatalf("Ask() error = %v; want %v", err, ErrUserAborted)
+	}
+
+	want := "When? 2026-10-19\n" +
+		"    October 2026\n" +
+		"Su Mo Tu We Th Fr Sa\n" +
+		"             1  2  3\n" +
+		" 4  5  6  7  8  9 10\n" +
+		"11 12 13 14 15 16 17\n" +
+		"18 19 20 21 22 23 24\n" +
+		"25 26 27 28 29 30 31"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestDateWeekStart(t *testing.T) {
+	date := NewDate().WeekStart(time.Monday)
+	date.current = day(2026, 2, 1)
+
+	lines := date.calendar()
+	if got, want := lines[1], "Mo Tu We Th Fr Sa Su"; got != want {
+		t.Errorf("calendar() weekdays = %q; want %q", got, want)
+	}
+	if got, want := lines[len(lines)-1], "23 24 25 26 27 28"; got != want {
+		t.Errorf("calendar() last week = %q; want %q", got, want)
+	}
+}
+
+func TestDateTyped(t *testing.T) {
+	result := day(2026, 10, 18)
+	term := pardontest.NewTerminal(40, 12)
+	term.Type("25.12.2026").Press(keys.Enter)
+
+	err := NewDate().Icon("").Title("When?").Layout("02.01.2006").Value(&result).Terminal(term).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if !result.Equal(day(2026, 12, 25)) {
+		t.Errorf("Ask() value = %v; want 2026-12-25", result)
+	}
+	if got, want := term.Screen(), "When? 25.12.2026"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestDateTypedError(t *testing.T) {
+	result := day(2026, 10, 18)
+	term := pardontest.NewTerminal(40, 12)
+	term.Type("2026-13-01").Press(keys.Enter).Send(keys.Ctrl('c'))
+
+	NewDate().Icon("").Title("When?").Value(&result).Terminal(term).Ask()
+
+	if !result.Equal(day(2026, 10, 18)) {
+		t.Errorf("Ask() value = %v; want the date to be kept", result)
+	}
+	if !term.Contains("* enter a date like YYYY-MM-DD") {
+		t.Errorf("Screen() = %q; want the layout error", term.Screen())
+	}
+}
+
+func TestDateParse(t *testing.T) {
+	result := day(2026, 6, 1)
+	date := NewDate().Min(day(2026, 1, 1)).Max(day(2026, 12, 31)).Value(&result)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr string
+	}{
+		{"2026-06-15", day(2026, 6, 15), ""},
+		{" 2026-12-31 ", day(2026, 12, 31), ""},
+		{"15/06/2026", time.Time{}, "enter a date like YYYY-MM-DD"},
+		{"2027-01-01", time.Time{}, "enter a date from 2026-01-01 to 2026-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := date.parse(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("parse(%q) error = %v; want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("parse(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+
+	if err := NewDate().Min(day(2026, 1, 1)).checkRange(day(2025, 12, 31)); err == nil ||
+		err.Error() != "enter a date no earlier than 2026-01-01" {
+		t.Errorf("checkRange() error = %v; want the minimum", err)
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	result := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+	term := pardontest.NewTerminal(40, 12)
+	term.Press(keys.Right, keys.Tab, keys.Up, keys.Tab, keys.Down, keys.Down, keys.Enter)
+
+	if err := NewDateTime().Icon("").Title("When?").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	// The hour wraps around within the day chosen on the calendar
+	want := time.Date(2026, 10, 19, 0, 28, 0, 0, time.UTC)
+	if !result.Equal(want) {
+		t.Errorf("Ask() value = %v; want %v", result, want)
+	}
+	if got := term.Screen(); got != "When? 2026-10-19 00:28" {
+		t.Errorf("Screen() = %q; want %q", got, "When? 2026-10-19 00:28")
+	}
+}
+
+func TestDateAccessible(t *testing.T) {
+	result := day(2026, 10, 18)
+	term := pardontest.NewTerminal(60, 10)
+	term.Type("tomorrow").Press(keys.Enter).Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	if err := NewDate().Icon("").Title("When?").Value(&result).Session(session).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	want := "When?\n" +
+		"Enter a date (YYYY-MM-DD) [2026-10-18]: tomorrow\n" +
+		"Error: enter a date like YYYY-MM-DD\n" +
+		"Enter a date (YYYY-MM-DD) [2026-10-18]:\n" +
+		"Selected: 2026-10-18"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestDateAnswer(t *testing.T) {
+	result := day(2026, 1, 1)
+	date := NewDate().Max(day(2026, 12, 31)).Value(&result)
+
+	if err := date.answer("2026-03-04"); err != nil || !result.Equal(day(2026, 3, 4)) {
+		t.Errorf("answer(\"2026-03-04\") = %v, value %v", err, result)
+	}
+	if err := date.answer(time.Date(2026, 5, 6, 7, 8, 0, 0, time.UTC)); err != nil || !result.Equal(day(2026, 5, 6)) {
+		t.Errorf("answer(time) = %v, value %v; want 2026-05-06", err, result)
+	}
+	if err := date.answer("2027-01-01"); err == nil {
+		t.Error("answer(\"2027-01-01\") error = nil; want a range error")
+	}
+}
+
+func TestDateTimeMinSeconds(t *testing.T) {
+	min := time.Date(2026, 10, 18, 9, 15, 30, 0, time.UTC)
+	date := NewDateTime().Min(min)
+
+	if got, want := date.clamp(day(2026, 10, 18)), time.Date(2026, 10, 18, 9, 16, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("clamp() = %v; want %v", got, want)
+	}
+	if got := NewDate().Min(min).clamp(day(2026, 10, 1)); !got.Equal(day(2026, 10, 18)) {
+		t.Errorf("date clamp() = %v; want 2026-10-18", got)
+	}
+}
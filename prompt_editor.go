@@ -0,0 +1,399 @@
+package pardon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Editor represents a prompt for multi-line text written in an external
+// editor. The text is edited in a temporary file and the answer shows its
+// first line.
+type Editor struct {
+	name       string
+	session    *Session
+	term       tui.Terminal
+	icon       eval[string]
+	title      eval[string]
+	editor     string
+	ext        string
+	value      *string
+	answerFn   func(string) string
+	validateFn func(string) error
+	screen     *tui.Renderer
+}
+
+// NewEditor creates a new Editor prompt instance.
+func NewEditor() *Editor {
+	e := &Editor{
+		icon:  eval[string]{val: Icons.QuestionMark},
+		title: eval[string]{val: ""},
+		ext:   ".txt",
+	}
+	e.setSession(defaultSession)
+	return e
+}
+
+// Terminal sets the terminal the editor prompt reads from and renders to.
+func (e *Editor) Terminal(t Terminal) *Editor {
+	e.setTerminal(t)
+	return e
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (e *Editor) setTerminal(t Terminal) {
+	if t != nil {
+		e.term = t
+	}
+}
+
+// Session makes the editor prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (e *Editor) Session(s *Session) *Editor {
+	e.setSession(s)
+	return e
+}
+
+// setSession moves the prompt to s.
+func (e *Editor) setSession(s *Session) {
+	d := s.defaults()
+	e.session = s
+	e.term = s.Terminal()
+	e.icon.defaultFn = d.iconFn
+	e.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the text in an AnswerSource.
+func (e *Editor) Name(name string) *Editor {
+	e.name = name
+	return e
+}
+
+// Title sets the prompt text.
+func (e *Editor) Title(title string) *Editor {
+	e.title.val = title
+	e.title.fn = nil
+	return e
+}
+
+// TitleFunc sets a dynamic title function.
+func (e *Editor) TitleFunc(fn func(string) string) *Editor {
+	e.title.fn = fn
+	return e
+}
+
+// Icon sets the prompt icon.
+func (e *Editor) Icon(s string) *Editor {
+	e.icon.val = s
+	e.icon.fn = nil
+	return e
+}
+
+// IconFunc sets a dynamic icon function.
+func (e *Editor) IconFunc(fn func(string) string) *Editor {
+	e.icon.fn = fn
+	return e
+}
+
+// Value sets the text to fill in, which the editor opens with.
+func (e *Editor) Value(value *string) *Editor {
+	e.value = value
+	return e
+}
+
+// Editor sets the editor command, a program followed by any arguments
+// separated by spaces, such as "code --wait". By default the VISUAL or
+// EDITOR environment variable is used, falling back to vi, or notepad on
+// Windows.
+func (e *Editor) Editor(cmd string) *Editor {
+	e.editor = cmd
+	return e
+}
+
+// Extension sets the extension of the temporary file, such as ".md" or
+// ".yaml", which editors use to pick a syntax. It defaults to ".txt".
+func (e *Editor) Extension(ext string) *Editor {
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	e.ext = ext
+	return e
+}
+
+// AnswerFunc sets a function to transform the final answer.
+func (e *Editor) AnswerFunc(fn func(string) string) *Editor {
+	e.answerFn = fn
+	return e
+}
+
+// Validate sets a check run on the edited text. Text that fails it is
+// opened in the editor again.
+func (e *Editor) Validate(fn func(string) error) *Editor {
+	e.validateFn = fn
+	return e
+}
+
+// getAnswerFunc returns the formatted text for the final answer display.
+func (e *Editor) getAnswerFunc(answer string) string {
+	if e.answerFn != nil {
+		return e.answerFn(answer)
+	}
+
+	if fn := e.session.defaults().answerFn; fn != nil {
+		return fn(answer)
+	}
+
+	return answer
+}
+
+// command returns the editor command to run.
+func (e *Editor) command() string {
+	if strings.TrimSpace(e.editor) != "" {
+		return e.editor
+	}
+	return tui.DefaultEditor()
+}
+
+// editorName returns the name of the editor program, for the hint shown
+// before it is opened.
+func (e *Editor) editorName() string {
+	return filepath.Base(strings.Fields(e.command())[0])
+}
+
+// check runs the validation function on text.
+func (e *Editor) check(text string) error {
+	if e.validateFn == nil {
+		return nil
+	}
+	return e.validateFn(text)
+}
+
+// edit opens text in the editor and returns it once the editor exits. A
+// trailing line ending, which most editors add, is removed and Windows
+// line endings are converted.
+func (e *Editor) edit(ctx context.Context, text string) (string, error) {
+	f, err := os.CreateTemp("", "pardon-*"+e.ext)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := tui.RunEditor(ctx, e.term, e.command(), f.Name()); err != nil {
+		return "", err
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+
+	edited := strings.ReplaceAll(string(b), "\r\n", "\n")
+	return strings.TrimSuffix(edited, "\n"), nil
+}
+
+// summarize returns the first line of text with content, shortened, and
+// how many lines follow it.
+func summarize(text string) string {
+	const maxWidth = 50
+
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+	first := strings.TrimSpace(lines[0])
+	if first == "" {
+		return "(empty)"
+	}
+
+	if tui.StringWidth(first) > maxWidth {
+		var short strings.Builder
+		width := 0
+		for _, g := range tui.Graphemes(first) {
+			if width += tui.StringWidth(g); width > maxWidth-1 {
+				break
+			}
+			short.WriteString(g)
+		}
+		first = short.String() + "…"
+	}
+
+	switch more := len(lines) - 1; more {
+	case 0:
+		return first
+	case 1:
+		return first + " (+1 more line)"
+	default:
+		return fmt.Sprintf("%s (+%d more lines)", first, more)
+	}
+}
+
+// promptName returns the name of the editor prompt.
+func (e *Editor) promptName() string {
+	return e.name
+}
+
+// answer sets the value from a scripted answer after validating it.
+func (e *Editor) answer(v any) error {
+	if e.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	if err := e.check(s); err != nil {
+		return err
+	}
+
+	*e.value = s
+	return nil
+}
+
+// Ask displays the editor prompt and waits for the text to be edited.
+func (e *Editor) Ask() error {
+	return e.AskContext(context.Background())
+}
+
+// AskContext displays the editor prompt until the text is edited or ctx is
+// done, closing the editor and erasing the prompt and returning an error
+// wrapping ctx.Err() in the latter case.
+func (e *Editor) AskContext(ctx context.Context) error {
+	if e.title.val == "" && e.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if e.value == nil {
+		return ErrNoValue
+	}
+
+	if !tui.IsInteractive(e.term) || e.session.Accessible() {
+		return e.askLines(ctx)
+	}
+
+	defer tui.Guard(e.term)()
+	defer func() {
+		fmt.Fprint(e.term, ansi.ShowCursor)
+	}()
+
+	e.screen = tui.NewRenderer(e.term)
+	fmt.Fprint(e.term, ansi.HideCursor)
+
+	text := *e.value
+	var problem string
+	for {
+		if err := e.waitForEnter(ctx, problem); err != nil {
+			return err
+		}
+
+		// The editor takes over the screen until it exits
+		e.screen.Erase()
+		fmt.Fprint(e.term, ansi.ShowCursor)
+		edited, err := e.edit(ctx, text)
+		fmt.Fprint(e.term, ansi.HideCursor)
+		if err != nil {
+			return err
+		}
+
+		text = edited
+		if err := e.check(text); err != nil {
+			problem = err.Error()
+			continue
+		}
+
+		*e.value = text
+		e.screen.Finish(e.icon.Get() + e.title.Get() + " " + e.getAnswerFunc(summarize(text)))
+		return nil
+	}
+}
+
+// waitForEnter shows the prompt, with the reason the text was rejected if
+// any, until Enter is pressed to open the editor.
+func (e *Editor) waitForEnter(ctx context.Context, problem string) error {
+	reader := tui.NewKeyReader(e.term)
+	defer reader.Close()
+
+	lines := []string{
+		e.icon.Get() + e.title.Get() + " " + ansi.Dim + "[Enter to open " + e.editorName() + "]" + ansi.ResetIntensity,
+	}
+	if problem != "" {
+		lines = append(lines, tui.FormatError(problem))
+	}
+	e.screen.Draw(lines)
+
+	for {
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				e.screen.Erase()
+			}
+			return err
+		}
+
+		switch {
+		case ev.Key == keys.Resize:
+			e.screen.Draw(lines)
+		case ev.Key == keys.Resume:
+			e.screen.Reset()
+			fmt.Fprint(e.term, ansi.HideCursor)
+			e.screen.Draw(lines)
+		case ev == keys.Ctrl('c'):
+			return ErrUserAborted
+		case ev.Key == keys.Enter:
+			return nil
+		}
+	}
+}
+
+// askLines edits the text a line at a time for accessible mode, which
+// opens the editor once Enter is pressed, and for non-interactive
+// terminals, which read lines up to one holding only a full stop.
+func (e *Editor) askLines(ctx context.Context) error {
+	fmt.Fprintf(e.term, "%s%s\n", e.icon.Get(), e.title.Get())
+
+	lines := newLineAsker(e.term, e.session)
+	text := *e.value
+	for {
+		var err error
+		if tui.IsInteractive(e.term) {
+			if _, err = lines.read(ctx, fmt.Sprintf("Press Enter to open %s: ", e.editorName())); err != nil {
+				return err
+			}
+			text, err = e.edit(ctx, text)
+		} else {
+			text, err = e.readText(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := e.check(text); err != nil {
+			lines.reject(text, err)
+			continue
+		}
+
+		*e.value = text
+		summary := summarize(text)
+		lines.accept(text, e.getAnswerFunc(summary), summary)
+		return nil
+	}
+}
+
+// readText reads lines of text up to one holding only a full stop, or the
+// end of input.
+func (e *Editor) readText(ctx context.Context) (string, error) {
+	fmt.Fprintln(e.term, `Enter text, ending with a line holding only ".":`)
+	return tui.ReadText(ctx, e.term)
+}
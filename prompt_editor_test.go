@@ -0,0 +1,168 @@
+package pardon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+// fakeEditor writes a shell script run as the editor, which receives the
+// file to edit as $1, and returns its path.
+func fakeEditor(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editors are shell scripts")
+	}
+
+	path := filepath.Join(t.TempDir(), "fake-editor")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEditorCreation(t *testing.T) {
+	editor := NewEditor()
+
+	if editor.ext != ".txt" {
+		t.Errorf("ext = %q; want .txt", editor.ext)
+	}
+	if editor.Extension("md").ext != ".md" {
+		t.Errorf("Extension(\"md\") ext = %q; want .md", editor.ext)
+	}
+
+	var result string
+	if err := editor.Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewEditor().Title("Notes:").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+}
+
+func TestEditorScreen(t *testing.T) {
+	cmd := fakeEditor(t, `printf 'Fix the bug\n\nIt was bad.\n' > "$1"`)
+
+	var result string
+	term := pardontest.NewTerminal(60, 10)
+	term.Press(keys.Enter)
+
+	if err := NewEditor().Icon("").Title("Message:").Editor(cmd).Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if want := "Fix the bug\n\nIt was bad."; result != want {
+		t.Errorf("Ask() value = %q; want %q", result, want)
+	}
+	if got, want := term.Screen(), "Message: Fix the bug (+2 more lines)"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestEditorKeepsValue(t *testing.T) {
+	cmd := fakeEditor(t, `printf ' and more' >> "$1"`)
+
+	result := "draft"
+	term := pardontest.NewTerminal(60, 10)
+	term.Press(keys.Enter)
+
+	if err := NewEditor().Title("Notes").Editor(cmd).Extension(".md").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != "draft and more" {
+		t.Errorf("Ask() value = %q; want %q", result, "draft and more")
+	}
+}
+
+func TestEditorFromEnvironment(t *testing.T) {
+	t.Setenv("VISUAL", fakeEditor(t, `echo visual > "$1"`))
+	t.Setenv("EDITOR", "false")
+
+	var result string
+	term := pardontest.NewTerminal(60, 10)
+	term.Press(keys.Enter)
+
+	if err := NewEditor().Title("Notes").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != "visual" {
+		t.Errorf("Ask() value = %q; want %q", result, "visual")
+	}
+}
+
+func TestEditorValidate(t *testing.T) {
+	cmd := fakeEditor(t, `printf x >> "$1"`)
+	long := func(s string) error {
+		if len(s) < 2 {
+			return errors.New("too short")
+		}
+		return nil
+	}
+
+	// The rejected text is opened again once Enter is pressed
+	var result string
+	term := pardontest.NewTerminal(60, 10)
+	term.Press(keys.Enter, keys.Enter)
+
+	if err := NewEditor().Icon("").Title("Notes").Editor(cmd).Validate(long).Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "xx" {
+		t.Errorf("Ask() value = %q; want %q", result, "xx")
+	}
+	if !strings.Contains(term.Output(), "[Enter to open fake-editor]") || !strings.Contains(term.Output(), "* too short") {
+		t.Errorf("Output() = %q; want the hint and the error", term.Output())
+	}
+	if got := term.Screen(); got != "Notes xx" {
+		t.Errorf("Screen() = %q; want %q", got, "Notes xx")
+	}
+}
+
+func TestEditorFailure(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(60, 10)
+	term.Press(keys.Enter)
+
+	err := NewEditor().Title("Notes").Editor(filepath.Join(t.TempDir(), "missing")).Value(&result).Terminal(term).Ask()
+	if err == nil || !strings.Contains(err.Error(), "running editor: ") {
+		t.Errorf("Ask() error = %v; want the editor to fail", err)
+	}
+}
+
+func TestEditorLines(t *testing.T) {
+	var result string
+	term := NewLineTerminal(strings.NewReader("first\nsecond\n.\n"), &strings.Builder{})
+
+	if err := NewEditor().Title("Notes").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != "first\nsecond" {
+		t.Errorf("Ask() value = %q; want %q", result, "first\nsecond")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", "(empty)"},
+		{"one line", "one line"},
+		{"\n\ntitle\nbody", "title (+1 more line)"},
+		{"a\nb\nc\n", "a (+2 more lines)"},
+		{strings.Repeat("x", 60), strings.Repeat("x", 49) + "…"},
+	}
+
+	for _, tt := range tests {
+		if got := summarize(tt.text); got != tt.want {
+			t.Errorf("summarize(%q) = %q; want %q", tt.text, got, tt.want)
+		}
+	}
+}
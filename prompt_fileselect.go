@@ -0,0 +1,695 @@
+package pardon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// FileSelect represents a prompt choosing a file by browsing directories in
+// a scrolling list like that of Select.
+type FileSelect struct {
+	name         string
+	session      *Session
+	term         tui.Terminal
+	icon         eval[string]
+	title        eval[string]
+	cursor       eval[string]
+	dirMarker    string
+	fileMarker   string
+	cursorPos    int
+	scrollOffset int
+	screen       *tui.Renderer
+	fsys         fs.FS
+	globs        []string
+	hidden       bool
+	dir          string // Directory listed, relative to the root
+	entries      []fileEntry
+	errMsg       string
+	answerFn     func(string) string
+	selectFn     func(string) string
+	value        *string
+}
+
+// fileEntry is a file or directory listed by a FileSelect.
+type fileEntry struct {
+	name  string // Base name, or ".." for the parent directory
+	isDir bool
+	size  int64
+}
+
+// label returns the name of e, with a slash after directories.
+func (e fileEntry) label() string {
+	if e.isDir {
+		return e.name + "/"
+	}
+	return e.name
+}
+
+// NewFileSelect creates a new FileSelect prompt instance browsing the
+// current directory.
+func NewFileSelect() *FileSelect {
+	fsel := &FileSelect{
+		icon:       eval[string]{val: Icons.QuestionMark},
+		title:      eval[string]{val: ""},
+		cursor:     eval[string]{val: "> "},
+		dirMarker:  "▸ ",
+		fileMarker: "  ",
+	}
+	fsel.setSession(defaultSession)
+	return fsel
+}
+
+// Terminal sets the terminal the prompt reads from and renders to.
+func (fsel *FileSelect) Terminal(t Terminal) *FileSelect {
+	fsel.setTerminal(t)
+	return fsel
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (fsel *FileSelect) setTerminal(t Terminal) {
+	if t != nil {
+		fsel.term = t
+	}
+}
+
+// Session makes the prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (fsel *FileSelect) Session(s *Session) *FileSelect {
+	fsel.setSession(s)
+	return fsel
+}
+
+// setSession moves the prompt to s.
+func (fsel *FileSelect) setSession(s *Session) {
+	d := s.defaults()
+	fsel.session = s
+	fsel.term = s.Terminal()
+	fsel.icon.defaultFn = d.iconFn
+	fsel.title.defaultFn = d.titleFn
+	fsel.cursor.defaultFn = d.cursorFn
+}
+
+// Name sets the name used to look up the file in an AnswerSource.
+func (fsel *FileSelect) Name(name string) *FileSelect {
+	fsel.name = name
+	return fsel
+}
+
+// Title sets the prompt title text that will be displayed to the user.
+func (fsel *FileSelect) Title(title string) *FileSelect {
+	fsel.title.val = title
+	fsel.title.fn = nil
+	return fsel
+}
+
+// TitleFunc sets a function to dynamically format the prompt title.
+func (fsel *FileSelect) TitleFunc(fn func(string) string) *FileSelect {
+	fsel.title.fn = fn
+	return fsel
+}
+
+// Icon sets the icon displayed before the prompt title.
+func (fsel *FileSelect) Icon(icon string) *FileSelect {
+	fsel.icon.val = icon
+	fsel.icon.fn = nil
+	return fsel
+}
+
+// IconFunc sets a function to dynamically format the prompt icon.
+func (fsel *FileSelect) IconFunc(fn func(string) string) *FileSelect {
+	fsel.icon.fn = fn
+	return fsel
+}
+
+// Cursor sets the cursor symbol displayed next to the highlighted entry.
+func (fsel *FileSelect) Cursor(cursor string) *FileSelect {
+	fsel.cursor.val = cursor
+	fsel.cursor.fn = nil
+	return fsel
+}
+
+// CursorFunc sets a function to dynamically format the cursor symbol.
+func (fsel *FileSelect) CursorFunc(fn func(string) string) *FileSelect {
+	fsel.cursor.fn = fn
+	return fsel
+}
+
+// Markers sets the symbols shown before directories and files.
+func (fsel *FileSelect) Markers(dir, file string) *FileSelect {
+	fsel.dirMarker, fsel.fileMarker = dir, file
+	return fsel
+}
+
+// Root makes the prompt browse fsys instead of the local filesystem. The
+// path chosen is then slash-separated and relative to the root of fsys,
+// above which browsing can't go.
+func (fsel *FileSelect) Root(fsys fs.FS) *FileSelect {
+	fsel.fsys = fsys
+	return fsel
+}
+
+// Glob limits the files listed to those whose names match one of the
+// patterns, in the syntax of path.Match, such as "*.go". Directories are
+// always listed.
+func (fsel *FileSelect) Glob(patterns ...string) *FileSelect {
+	fsel.globs = patterns
+	return fsel
+}
+
+// Hidden sets whether files and directories whose names start with a dot
+// are listed at first. Pressing . toggles them while browsing.
+func (fsel *FileSelect) Hidden(show bool) *FileSelect {
+	fsel.hidden = show
+	return fsel
+}
+
+// Value sets the pointer where the chosen path will be stored. A path it
+// already holds is where browsing starts: in the directory it names, or
+// beside the file it names. Without Root, browsing can go up to the root of
+// the filesystem, and the path chosen is absolute if the value held was,
+// and otherwise relative to the current directory.
+func (fsel *FileSelect) Value(value *string) *FileSelect {
+	fsel.value = value
+	return fsel
+}
+
+// AnswerFunc sets a function to format the final answer display.
+func (fsel *FileSelect) AnswerFunc(fn func(string) string) *FileSelect {
+	fsel.answerFn = fn
+	return fsel
+}
+
+// SelectFunc sets a function to format the highlighted entry.
+func (fsel *FileSelect) SelectFunc(fn func(string) string) *FileSelect {
+	fsel.selectFn = fn
+	return fsel
+}
+
+// getSelectFunc returns the formatted text for the highlighted entry.
+func (fsel *FileSelect) getSelectFunc(s string) string {
+	if fsel.selectFn != nil {
+		return fsel.selectFn(s)
+	}
+
+	if fn := fsel.session.defaults().selectFn; fn != nil {
+		return fn(s)
+	}
+
+	return s
+}
+
+// getAnswerFunc returns the formatted text for the final answer display.
+func (fsel *FileSelect) getAnswerFunc(answer string) string {
+	if fsel.answerFn != nil {
+		return fsel.answerFn(answer)
+	}
+
+	if fn := fsel.session.defaults().answerFn; fn != nil {
+		return fn(answer)
+	}
+
+	return answer
+}
+
+// toRoot returns name, as given to the prompt, as a path it browses:
+// slash-separated within the fs.FS given to Root, or otherwise a cleaned
+// path of the operating system.
+func (fsel *FileSelect) toRoot(name string) (string, error) {
+	if fsel.fsys != nil {
+		return fsName(name)
+	}
+	return filepath.Clean(name), nil
+}
+
+// readDir returns the entries of the directory dir.
+func (fsel *FileSelect) readDir(dir string) ([]fs.DirEntry, error) {
+	if fsel.fsys != nil {
+		return fs.ReadDir(fsel.fsys, dir)
+	}
+	return os.ReadDir(dir)
+}
+
+// stat returns information on the file name, following symlinks.
+func (fsel *FileSelect) stat(name string) (fs.FileInfo, error) {
+	if fsel.fsys != nil {
+		return fs.Stat(fsel.fsys, name)
+	}
+	return os.Stat(name)
+}
+
+// join returns the path of the file name within dir.
+func (fsel *FileSelect) join(dir, name string) string {
+	if fsel.fsys != nil {
+		return path.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
+
+// parent returns the directory holding name and the base name of name
+// within it, or false at the top. Without Root, the top is the root of the
+// filesystem or volume rather than the current directory.
+func (fsel *FileSelect) parent(name string) (dir, base string, ok bool) {
+	if fsel.fsys != nil {
+		if name == "." {
+			return "", "", false
+		}
+		return path.Dir(name), path.Base(name), true
+	}
+
+	abs, err := filepath.Abs(name)
+	if err != nil || filepath.Dir(abs) == abs {
+		return "", "", false
+	}
+	if filepath.IsAbs(name) {
+		return filepath.Dir(name), filepath.Base(name), true
+	}
+	return filepath.Join(name, ".."), filepath.Base(abs), true // Relative paths stay relative
+}
+
+// matches reports whether the file name matches one of the glob patterns.
+func (fsel *FileSelect) matches(name string) bool {
+	if len(fsel.globs) == 0 {
+		return true
+	}
+
+	for _, pattern := range fsel.globs {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGlobs reports the first malformed glob pattern.
+func (fsel *FileSelect) checkGlobs() error {
+	for _, pattern := range fsel.globs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("glob %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// list returns the entries of dir to show, directories first and each
+// sorted by name, after a ".." entry unless dir is the top.
+func (fsel *FileSelect) list(dir string) ([]fileEntry, error) {
+	dirEntries, err := fsel.readDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []fileEntry
+	if _, _, ok := fsel.parent(dir); ok {
+		entries = append(entries, fileEntry{name: "..", isDir: true})
+	}
+
+	for _, de := range dirEntries {
+		name := de.Name()
+		if strings.HasPrefix(name, ".") && !fsel.hidden {
+			continue
+		}
+
+		info, err := de.Info()
+		if de.Type()&fs.ModeSymlink != 0 {
+			info, err = fsel.stat(fsel.join(dir, name))
+		}
+		if err != nil {
+			continue
+		}
+
+		if !info.IsDir() && !fsel.matches(name) {
+			continue
+		}
+		entries = append(entries, fileEntry{name: name, isDir: info.IsDir(), size: info.Size()})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].isDir && !entries[j].isDir
+	})
+	return entries, nil
+}
+
+// open lists dir, placing the cursor on the entry named name if present
+// and otherwise on the first entry after "..".
+func (fsel *FileSelect) open(dir, name string) error {
+	entries, err := fsel.list(dir)
+	if err != nil {
+		return err
+	}
+
+	fsel.dir, fsel.entries = dir, entries
+	fsel.cursorPos, fsel.scrollOffset = 0, 0
+	if len(entries) > 1 && entries[0].name == ".." {
+		fsel.cursorPos = 1
+	}
+	for i, e := range entries {
+		if e.name == name {
+			fsel.cursorPos = i
+			break
+		}
+	}
+	return nil
+}
+
+// start opens the directory browsing starts in, taken from the value.
+func (fsel *FileSelect) start() error {
+	name, err := fsel.toRoot(*fsel.value)
+	if *fsel.value == "" || err != nil {
+		return fsel.open(".", "")
+	}
+
+	if info, err := fsel.stat(name); err == nil && info.IsDir() {
+		return fsel.open(name, "")
+	}
+	if dir, base, ok := fsel.parent(name); ok && fsel.open(dir, base) == nil {
+		return nil
+	}
+	return fsel.open(".", "")
+}
+
+// enter opens the directory entry e, or the parent directory for "..". A
+// directory that can't be read is reported on the error line.
+func (fsel *FileSelect) enter(e fileEntry) {
+	var err error
+	if e.name == ".." {
+		if dir, base, ok := fsel.parent(fsel.dir); ok {
+			err = fsel.open(dir, base)
+		}
+	} else {
+		err = fsel.open(fsel.join(fsel.dir, e.name), "")
+	}
+
+	fsel.errMsg = ""
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+	if err != nil {
+		fsel.errMsg = fmt.Sprintf("can't open %s: %v", e.label(), err)
+	}
+}
+
+// current returns the highlighted entry.
+func (fsel *FileSelect) current() (fileEntry, bool) {
+	if fsel.cursorPos >= len(fsel.entries) {
+		return fileEntry{}, false
+	}
+	return fsel.entries[fsel.cursorPos], true
+}
+
+// formatSize returns n bytes in the largest unit that keeps it at least 1.
+func formatSize(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	size := float64(n) / 1024
+	for _, unit := range []string{"KB", "MB", "GB", "TB"} {
+		if size < 1024 || unit == "TB" {
+			return fmt.Sprintf("%.1f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return ""
+}
+
+// promptName returns the name of the file select prompt.
+func (fsel *FileSelect) promptName() string {
+	return fsel.name
+}
+
+// answer sets the value from a scripted path after checking it names a
+// listed file.
+func (fsel *FileSelect) answer(v any) error {
+	if fsel.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	name, err := fsel.toRoot(s)
+	if err != nil {
+		return err
+	}
+
+	info, err := fsel.stat(name)
+	_, base, _ := fsel.parent(name)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%s does not exist", s)
+	case info.IsDir():
+		return fmt.Errorf("%s is a directory", s)
+	case !fsel.matches(base):
+		return fmt.Errorf("%s does not match %s", s, strings.Join(fsel.globs, " or "))
+	}
+
+	*fsel.value = name
+	return nil
+}
+
+// Ask displays the file select prompt and waits for a file to be chosen.
+func (fsel *FileSelect) Ask() error {
+	return fsel.AskContext(context.Background())
+}
+
+// AskContext displays the file select prompt until a file is chosen or ctx
+// is done, in which case the listing is erased.
+func (fsel *FileSelect) AskContext(ctx context.Context) error {
+	if fsel.title.val == "" && fsel.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if fsel.value == nil {
+		return ErrNoValue
+	}
+
+	if err := fsel.checkGlobs(); err != nil {
+		return err
+	}
+
+	fsel.errMsg = ""
+	if err := fsel.start(); err != nil {
+		return err
+	}
+
+	if !tui.IsInteractive(fsel.term) || fsel.session.Accessible() {
+		return fsel.askLines(ctx)
+	}
+
+	defer tui.Guard(fsel.term)()
+	defer func() {
+		fmt.Fprint(fsel.term, ansi.ShowCursor)
+	}()
+	reader := tui.NewKeyReader(fsel.term)
+	defer reader.Close()
+
+	fsel.screen = tui.NewRenderer(fsel.term)
+	fmt.Fprint(fsel.term, ansi.HideCursor)
+	fsel.renderEntries()
+
+	for {
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				fsel.screen.Erase()
+			}
+			return err
+		}
+
+		if pos, ok := tui.MoveListCursor(ev, fsel.cursorPos, len(fsel.entries), fsel.pageSize()); ok {
+			fsel.cursorPos = pos
+			fsel.errMsg = ""
+			fsel.renderEntries()
+			continue
+		}
+
+		switch {
+		case ev.Key == keys.Resize:
+		case ev.Key == keys.Resume:
+			fsel.screen.Reset()
+			fmt.Fprint(fsel.term, ansi.HideCursor)
+		case ev == keys.Ctrl('c'):
+			return ErrUserAborted
+		case ev.Key == keys.Enter:
+			e, ok := fsel.current()
+			if !ok {
+				continue
+			}
+			if e.isDir {
+				fsel.enter(e)
+				break
+			}
+
+			chosen := fsel.join(fsel.dir, e.name)
+			*fsel.value = chosen
+			fsel.screen.Finish(fsel.icon.Get() + fsel.title.Get() + " " + fsel.getAnswerFunc(chosen))
+			return nil
+		case ev.Key == keys.Right:
+			if e, ok := fsel.current(); ok && e.isDir && e.name != ".." {
+				fsel.enter(e)
+			}
+		case ev.Key == keys.Backspace, ev.Key == keys.Left:
+			if _, _, ok := fsel.parent(fsel.dir); ok {
+				fsel.enter(fileEntry{name: "..", isDir: true})
+			}
+		case ev.IsPrintable() && ev.Rune == '.':
+			fsel.hidden = !fsel.hidden
+			name := ""
+			if e, ok := fsel.current(); ok {
+				name = e.name
+			}
+			if err := fsel.open(fsel.dir, name); err != nil {
+				fsel.errMsg = err.Error()
+			}
+		default:
+			continue
+		}
+
+		fsel.renderEntries()
+	}
+}
+
+// askLines lists the numbered entries of a directory and reads the choice
+// a line at a time for non-interactive terminals and accessible mode.
+// Choosing a directory lists it in turn.
+func (fsel *FileSelect) askLines(ctx context.Context) error {
+	fmt.Fprintf(fsel.term, "%s%s\n", fsel.icon.Get(), fsel.title.Get())
+
+	lines := newLineAsker(fsel.term, fsel.session)
+	for {
+		labels := make([]string, len(fsel.entries))
+		for i, e := range fsel.entries {
+			labels[i] = e.label()
+		}
+
+		fmt.Fprintf(fsel.term, "%s:\n", fsel.dirLabel())
+		if len(labels) == 0 {
+			return fmt.Errorf("no files in %s", fsel.dirLabel())
+		}
+		renderNumberedOptions(fsel.term, labels, func(int) string { return "" })
+
+		prompt := fmt.Sprintf("Enter a number (1-%d) or name: ", len(labels))
+
+		var idx int
+		for {
+			text, err := lines.read(ctx, prompt)
+			if err != nil {
+				return err
+			}
+
+			if idx, err = chooseOption(text, labels); err != nil {
+				lines.reject(text, err)
+				continue
+			}
+
+			e := fsel.entries[idx]
+			if !e.isDir {
+				chosen := fsel.join(fsel.dir, e.name)
+				*fsel.value = chosen
+				lines.accept(text, fsel.getAnswerFunc(chosen), chosen)
+				return nil
+			}
+
+			if fsel.enter(e); fsel.errMsg != "" {
+				lines.reject(text, errors.New(fsel.errMsg))
+				continue
+			}
+			if !lines.echoed {
+				fmt.Fprintln(fsel.term, text)
+			}
+			break
+		}
+	}
+}
+
+// dirLabel returns the directory listed, as shown above its entries.
+func (fsel *FileSelect) dirLabel() string {
+	switch {
+	case fsel.dir == ".":
+		return "./"
+	case fsel.fsys != nil:
+		return fsel.dir + "/"
+	case strings.HasSuffix(fsel.dir, string(filepath.Separator)):
+		return fsel.dir // The root of the filesystem or volume
+	}
+	return fsel.dir + string(filepath.Separator)
+}
+
+// pageSize returns the number of entries visible at once.
+func (fsel *FileSelect) pageSize() int {
+	return tui.TerminalHeight(fsel.term) - 4 // Space for prompt, error line and cursor movement
+}
+
+// entryLine returns the text of entry i, its name padded to width columns
+// so the sizes of files line up.
+func (fsel *FileSelect) entryLine(i, width int) string {
+	e := fsel.entries[i]
+	if e.isDir {
+		return fsel.dirMarker + e.label()
+	}
+
+	label := e.label()
+	pad := strings.Repeat(" ", max(width-tui.StringWidth(label), 0))
+	return fsel.fileMarker + label + pad + "  " + ansi.Dim + formatSize(e.size) + ansi.ResetIntensity
+}
+
+// renderEntries displays the directory listing and any error line.
+func (fsel *FileSelect) renderEntries() {
+	termHeight := fsel.pageSize()
+	selectCursor := fsel.cursor.Get()
+	padding := strings.Repeat(" ", tui.StringWidth(selectCursor))
+
+	header := fsel.icon.Get() + fsel.title.Get() + " " + ansi.Dim + fsel.dirLabel() + ansi.ResetIntensity
+
+	width := tui.TerminalWidth(fsel.term)
+	termHeight -= tui.ScreenRows([]string{header}, width) - 1
+	if fsel.errMsg != "" {
+		termHeight -= tui.ScreenRows([]string{tui.FormatError(fsel.errMsg)}, width) - 1
+	}
+
+	nameWidth := 0
+	for _, e := range fsel.entries {
+		nameWidth = max(nameWidth, tui.StringWidth(e.label()))
+	}
+
+	var end int
+	fsel.scrollOffset, end = tui.ScrollWindow(fsel.cursorPos, fsel.scrollOffset, len(fsel.entries), termHeight)
+	fsel.scrollOffset, end = tui.FitWindow(fsel.cursorPos, fsel.scrollOffset, end, termHeight, func(i int) int {
+		return tui.ScreenRows([]string{padding + fsel.entryLine(i, nameWidth)}, width)
+	})
+
+	lines := make([]string, 0, end-fsel.scrollOffset+3)
+	lines = append(lines, header)
+	if len(fsel.entries) == 0 {
+		lines = append(lines, padding+"no files")
+	}
+	for i := fsel.scrollOffset; i < end; i++ {
+		line := fsel.entryLine(i, nameWidth)
+
+		if i == fsel.cursorPos {
+			lines = append(lines, fsel.getSelectFunc(selectCursor)+fsel.getSelectFunc(line))
+		} else {
+			lines = append(lines, padding+line)
+		}
+	}
+
+	if fsel.errMsg != "" {
+		lines = append(lines, tui.FormatError(fsel.errMsg))
+	}
+
+	fsel.screen.Draw(lines)
+}
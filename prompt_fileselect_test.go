@@ -0,0 +1,327 @@
+package pardon
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+// browseFS returns a small tree of files to browse.
+func browseFS() fstest.MapFS {
+	return fstest.MapFS{
+		"README.md":       {Data: make([]byte, 1536)},
+		"main.go":         {Data: []byte("package main\n")},
+		".env":            {Data: []byte("A=1\n")},
+		"cmd/tool/run.go": {},
+		"docs/guide.md":   {Data: make([]byte, 3<<20)},
+		"docs/notes.txt":  {},
+	}
+}
+
+func TestFileSelectCreation(t *testing.T) {
+	var result string
+	if err := NewFileSelect().Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewFileSelect().Title("File:").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+	if err := NewFileSelect().Title("File:").Glob("[").Value(&result).Ask(); !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("Ask() error = %v; want %v", err, path.ErrBadPattern)
+	}
+}
+
+func TestFileSelectScreen(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 12)
+	term.Press(keys.Down)
+
+	// Input runs out with the listing still shown
+	NewFileSelect().Icon("").Title("File:").Root(browseFS()).Value(&result).Terminal(term).Ask()
+
+	want := "File: ./\n" +
+		"  ▸ cmd/\n" +
+		"> ▸ docs/\n" +
+		"    README.md  1.5 KB\n" +
+		"    main.go    13 B"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestFileSelectBrowse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		keys  []keys.Key
+		fsel  func(*FileSelect) *FileSelect
+		want  string
+	}{
+		{"file in root", "", []keys.Key{keys.End, keys.Enter}, nil, "main.go"},
+		{"descend with enter", "", []keys.Key{keys.Down, keys.Enter, keys.Down, keys.Enter}, nil,
+			"docs/notes.txt"},
+		{"descend with right", "", []keys.Key{keys.Right, keys.Right, keys.Enter}, nil,
+			"cmd/tool/run.go"},
+		{"backspace goes up", "", []keys.Key{keys.Down, keys.Enter, keys.Backspace, keys.End, keys.Enter}, nil,
+			"main.go"},
+		{"parent entry goes up", "", []keys.Key{keys.Enter, keys.Up, keys.Enter, keys.Down, keys.Down, keys.Enter}, nil,
+			"README.md"},
+		{"starts beside value", "docs/notes.txt", []keys.Key{keys.Enter}, nil, "docs/notes.txt"},
+		{"starts in value directory", "docs", []keys.Key{keys.Enter}, nil, "docs/guide.md"},
+		{"glob", "", []keys.Key{keys.End, keys.Enter}, func(f *FileSelect) *FileSelect {
+			return f.Glob("*.md")
+		}, "README.md"},
+		{"hidden", "", []keys.Key{keys.Down, keys.Down, keys.Enter}, func(f *FileSelect) *FileSelect {
+			return f.Hidden(true)
+		}, ".env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value
+			term := pardontest.NewTerminal(40, 12)
+			term.Press(tt.keys...)
+
+			fsel := NewFileSelect().Icon("").Title("File:").Root(browseFS()).Value(&result).Terminal(term)
+			if tt.fsel != nil {
+				fsel = tt.fsel(fsel)
+			}
+			if err := fsel.Ask(); err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+
+			if result != tt.want {
+				t.Errorf("Ask() value = %q; want %q", result, tt.want)
+			}
+			if got, want := term.Screen(), "File: "+tt.want; got != want {
+				t.Errorf("Screen() = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFileSelectToggleHidden(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 12)
+	term.Type(".").Press(keys.Down, keys.Down, keys.Enter)
+
+	if err := NewFileSelect().Title("File:").Root(browseFS()).Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if result != ".env" {
+		t.Errorf("Ask() value = %q; want %q", result, ".env")
+	}
+}
+
+func TestFileSelectNoFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"directories kept", browseFS(), "File: ./\n> ▸ cmd/\n  ▸ docs/"},
+		{"nothing listed", fstest.MapFS{"notes.txt": {}}, "File: ./\n  no files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result string
+			term := pardontest.NewTerminal(40, 12)
+
+			NewFileSelect().Icon("").Title("File:").Root(tt.fsys).Glob("*.rs").
+				Value(&result).Terminal(term).Ask()
+
+			if got := term.Screen(); got != tt.want {
+				t.Errorf("Screen() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSelectLines(t *testing.T) {
+	var result string
+	var out strings.Builder
+	term := NewLineTerminal(strings.NewReader("docs\n9\nguide\n"), &out)
+
+	if err := NewFileSelect().Icon("").Title("File:").Root(browseFS()).Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "docs/guide.md" {
+		t.Errorf("Ask() value = %q; want %q", result, "docs/guide.md")
+	}
+	want := "File:\n" +
+		"./:\n" +
+		"  1) cmd/\n" +
+		"  2) docs/\n" +
+		"  3) README.md\n" +
+		"  4) main.go\n" +
+		"Enter a number (1-4) or name: docs\n" +
+		"docs/:\n" +
+		"  1) ../\n" +
+		"  2) guide.md\n" +
+		"  3) notes.txt\n" +
+		"Enter a number (1-3) or name: 9\n" +
+		"\x1b[31m* 9 is not between 1 and 3\x1b[0m\n" +
+		"Enter a number (1-3) or name: docs/guide.md\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+}
+
+func TestFileSelectAnswer(t *testing.T) {
+	var result string
+	fsel := NewFileSelect().Root(browseFS()).Glob("*.md").Value(&result)
+
+	tests := []struct {
+		answer  any
+		wantErr string
+	}{
+		{"docs/guide.md", ""},
+		{"docs", "docs is a directory"},
+		{"missing.md", "missing.md does not exist"},
+		{"main.go", "main.go does not match *.md"},
+	}
+
+	for _, tt := range tests {
+		err := fsel.answer(tt.answer)
+		if tt.wantErr == "" {
+			if err != nil || result != tt.answer {
+				t.Errorf("answer(%v) = %v, value %q", tt.answer, err, result)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("answer(%v) error = %v; want %q", tt.answer, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFileSelectWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "conf", "app"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join("conf", "app", "app.toml"), "top.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(filepath.Join(dir, "conf"))
+
+	tests := []struct {
+		name  string
+		value string
+		keys  []keys.Key
+		want  string
+	}{
+		{"relative value", "app", []keys.Key{keys.Enter}, filepath.Join("app", "app.toml")},
+		{"absolute value", filepath.Join(dir, "conf", "app"), []keys.Key{keys.Enter},
+			filepath.Join(dir, "conf", "app", "app.toml")},
+		{"absolute value outside", dir, []keys.Key{keys.End, keys.Enter}, filepath.Join(dir, "top.txt")},
+		{"up past working directory", "", []keys.Key{keys.Backspace, keys.End, keys.Enter},
+			filepath.Join("..", "top.txt")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value
+			term := pardontest.NewTerminal(60, 12)
+			term.Press(tt.keys...)
+
+			if err := NewFileSelect().Title("File:").Value(&result).Terminal(term).Ask(); err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Ask() value = %q; want %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileSelectParent(t *testing.T) {
+	top := string(filepath.Separator)
+	if vol := filepath.VolumeName(os.TempDir()); vol != "" {
+		top = vol + top
+	}
+
+	fsel := NewFileSelect()
+	if _, _, ok := fsel.parent(top); ok {
+		t.Errorf("parent(%q) found a parent; want the top of the filesystem", top)
+	}
+	if dir, base, ok := fsel.parent("."); !ok || dir != ".." || base == "" {
+		t.Errorf("parent(\".\") = %q, %q, %v; want \"..\" and the working directory", dir, base, ok)
+	}
+	if _, _, ok := fsel.Root(browseFS()).parent("."); ok {
+		t.Error("parent(\".\") found a parent above Root")
+	}
+}
+
+// lockedFS is a filesystem in which the directory locked can't be opened.
+type lockedFS struct {
+	fsys   fs.FS
+	locked string
+}
+
+func (l lockedFS) Open(name string) (fs.File, error) {
+	if name == l.locked {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return l.fsys.Open(name)
+}
+
+func TestFileSelectClearsError(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []keys.Key
+		want bool
+	}{
+		{"shown", []keys.Key{keys.Enter}, true},
+		{"cleared by moving", []keys.Key{keys.Enter, keys.Down}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result string
+			term := pardontest.NewTerminal(40, 12)
+			term.Press(tt.keys...)
+
+			// Input runs out with the listing still shown
+			NewFileSelect().Icon("").Title("File:").Root(lockedFS{browseFS(), "cmd"}).
+				Value(&result).Terminal(term).Ask()
+
+			if got := term.Contains("can't open cmd/: permission denied"); got != tt.want {
+				t.Errorf("Screen() = %q; want error shown %v", term.Screen(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{3 << 20, "3.0 MB"},
+		{5 << 40, "5.0 TB"},
+		{2048 << 40, "2048.0 TB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSize(tt.n); got != tt.want {
+			t.Errorf("formatSize(%d) = %q; want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,541 @@
+package pardon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// MultiSelect represents a selection prompt allowing several options to be chosen.
+type MultiSelect[T comparable] struct {
+	name         string
+	session      *Session
+	term         tui.Terminal
+	icon         eval[string]
+	title        eval[string]
+	cursor       eval[string]
+	checked      string
+	unchecked    string
+	cursorPos    int
+	scrollOffset int
+	screen       *tui.Renderer
+	options      []Option[T]
+	selected     []bool
+	min          int
+	max          int
+	errMsg       string
+	answerFn     func(string) string
+	selectFn     func(string) string
+	value        *[]T
+}
+
+// NewMultiSelect creates a new MultiSelect prompt instance.
+func NewMultiSelect[T comparable]() *MultiSelect[T] {
+	ms := &MultiSelect[T]{
+		icon:      eval[string]{val: Icons.QuestionMark},
+		title:     eval[string]{val: ""},
+		cursor:    eval[string]{val: "> "},
+		checked:   "[x] ",
+		unchecked: "[ ] ",
+		options:   make([]Option[T], 0),
+	}
+	ms.setSession(defaultSession)
+	return ms
+}
+
+// Terminal sets the terminal the prompt reads from and renders to.
+func (ms *MultiSelect[T]) Terminal(t Terminal) *MultiSelect[T] {
+	ms.setTerminal(t)
+	return ms
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (ms *MultiSelect[T]) setTerminal(t Terminal) {
+	if t != nil {
+		ms.term = t
+	}
+}
+
+// Session makes the prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (ms *MultiSelect[T]) Session(s *Session) *MultiSelect[T] {
+	ms.setSession(s)
+	return ms
+}
+
+// setSession moves the prompt to s.
+func (ms *MultiSelect[T]) setSession(s *Session) {
+	d := s.defaults()
+	ms.session = s
+	ms.term = s.Terminal()
+	ms.icon.defaultFn = d.iconFn
+	ms.title.defaultFn = d.titleFn
+	ms.cursor.defaultFn = d.cursorFn
+}
+
+// Name sets the name used to look up the selections in an AnswerSource.
+func (ms *MultiSelect[T]) Name(name string) *MultiSelect[T] {
+	ms.name = name
+	return ms
+}
+
+// Title sets the prompt title text that will be displayed to the user.
+func (ms *MultiSelect[T]) Title(title string) *MultiSelect[T] {
+	ms.title.val = title
+	ms.title.fn = nil
+	return ms
+}
+
+// TitleFunc sets a function to dynamically format the prompt title.
+func (ms *MultiSelect[T]) TitleFunc(fn func(string) string) *MultiSelect[T] {
+	ms.title.fn = fn
+	return ms
+}
+
+// Cursor sets the cursor symbol displayed next to the highlighted option.
+func (ms *MultiSelect[T]) Cursor(cursor string) *MultiSelect[T] {
+	ms.cursor.val = cursor
+	ms.cursor.fn = nil
+	return ms
+}
+
+// CursorFunc sets a function to dynamically format the cursor symbol.
+func (ms *MultiSelect[T]) CursorFunc(fn func(string) string) *MultiSelect[T] {
+	ms.cursor.fn = fn
+	return ms
+}
+
+// Markers sets the symbols displayed before checked and unchecked options.
+func (ms *MultiSelect[T]) Markers(checked, unchecked string) *MultiSelect[T] {
+	ms.checked = checked
+	ms.unchecked = unchecked
+	return ms
+}
+
+// Options sets the list of available options for selection.
+func (ms *MultiSelect[T]) Options(options ...Option[T]) *MultiSelect[T] {
+	if len(options) == 0 {
+		return ms
+	}
+
+	ms.options = options
+	return ms
+}
+
+// Value sets the pointer where the selected options' values will be stored.
+// Options whose values are already present in the slice start out checked.
+func (ms *MultiSelect[T]) Value(value *[]T) *MultiSelect[T] {
+	ms.value = value
+	return ms
+}
+
+// Min sets the minimum number of options that must be selected. It can't be
+// negative or more than the number of options.
+func (ms *MultiSelect[T]) Min(n int) *MultiSelect[T] {
+	ms.min = n
+	return ms
+}
+
+// Max sets the maximum number of options that may be selected.
+// A value of zero means there is no limit; a negative one is invalid.
+func (ms *MultiSelect[T]) Max(n int) *MultiSelect[T] {
+	ms.max = n
+	return ms
+}
+
+// Icon sets the icon displayed before the prompt title.
+func (ms *MultiSelect[T]) Icon(icon string) *MultiSelect[T] {
+	ms.icon.val = icon
+	ms.icon.fn = nil
+	return ms
+}
+
+// IconFunc sets a function to dynamically format the prompt icon.
+func (ms *MultiSelect[T]) IconFunc(fn func(string) string) *MultiSelect[T] {
+	ms.icon.fn = fn
+	return ms
+}
+
+// AnswerFunc sets a function to format the final answer display.
+func (ms *MultiSelect[T]) AnswerFunc(fn func(string) string) *MultiSelect[T] {
+	ms.answerFn = fn
+	return ms
+}
+
+// SelectFunc sets a function to format the highlighted option during selection.
+func (ms *MultiSelect[T]) SelectFunc(fn func(string) string) *MultiSelect[T] {
+	ms.selectFn = fn
+	return ms
+}
+
+// getSelectFunc returns the formatted text for the highlighted option.
+func (ms *MultiSelect[T]) getSelectFunc(s string) string {
+	if ms.selectFn != nil {
+		return ms.selectFn(s)
+	}
+
+	if fn := ms.session.defaults().selectFn; fn != nil {
+		return fn(s)
+	}
+
+	return s
+}
+
+// getAnswerFunc returns the formatted text for the final answer display.
+func (ms *MultiSelect[T]) getAnswerFunc(answer string) string {
+	if ms.answerFn != nil {
+		return ms.answerFn(answer)
+	}
+
+	if fn := ms.session.defaults().answerFn; fn != nil {
+		return fn(answer)
+	}
+
+	return answer
+}
+
+// count returns the number of currently selected options.
+func (ms *MultiSelect[T]) count() int {
+	n := 0
+	for _, s := range ms.selected {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// initSelected marks the options whose values are already held by the bound slice.
+func (ms *MultiSelect[T]) initSelected() {
+	ms.selected = make([]bool, len(ms.options))
+	for i, opt := range ms.options {
+		for _, v := range *ms.value {
+			if opt.Value == v {
+				ms.selected[i] = true
+				break
+			}
+		}
+	}
+}
+
+// toggle flips the option under the cursor, respecting the maximum limit.
+func (ms *MultiSelect[T]) toggle() {
+	if !ms.selected[ms.cursorPos] && ms.max > 0 && ms.count() >= ms.max {
+		ms.errMsg = fmt.Sprintf("select at most %d %s", ms.max, pluralOptions(ms.max))
+		return
+	}
+	ms.selected[ms.cursorPos] = !ms.selected[ms.cursorPos]
+}
+
+// selectAll checks every option, or clears them all if every option is already checked.
+func (ms *MultiSelect[T]) selectAll() {
+	if ms.count() == len(ms.options) {
+		for i := range ms.selected {
+			ms.selected[i] = false
+		}
+		return
+	}
+
+	if ms.max > 0 && len(ms.options) > ms.max {
+		ms.errMsg = fmt.Sprintf("select at most %d %s", ms.max, pluralOptions(ms.max))
+		return
+	}
+
+	for i := range ms.selected {
+		ms.selected[i] = true
+	}
+}
+
+// invert flips every option, respecting the maximum limit.
+func (ms *MultiSelect[T]) invert() {
+	if ms.max > 0 && len(ms.options)-ms.count() > ms.max {
+		ms.errMsg = fmt.Sprintf("select at most %d %s", ms.max, pluralOptions(ms.max))
+		return
+	}
+
+	for i := range ms.selected {
+		ms.selected[i] = !ms.selected[i]
+	}
+}
+
+// selection collects the selected values and the labels shown as the final answer.
+func (ms *MultiSelect[T]) selection() ([]T, string) {
+	values := make([]T, 0, len(ms.options))
+	labels := make([]string, 0, len(ms.options))
+
+	for i, opt := range ms.options {
+		if ms.selected[i] {
+			values = append(values, opt.Value)
+			labels = append(labels, opt.Key)
+		}
+	}
+
+	return values, strings.Join(labels, ", ")
+}
+
+// promptName returns the name of the multi-select prompt.
+func (ms *MultiSelect[T]) promptName() string {
+	return ms.name
+}
+
+// answer selects the options matching a scripted list of answers, checking
+// the selection limits.
+func (ms *MultiSelect[T]) answer(v any) error {
+	if ms.value == nil {
+		return ErrNoValue
+	}
+
+	list, err := answerList(v)
+	if err != nil {
+		return err
+	}
+
+	ms.selected = make([]bool, len(ms.options))
+	for _, item := range list {
+		idx, err := answerOption(ms.options, item)
+		if err != nil {
+			return err
+		}
+		ms.selected[idx] = true
+	}
+
+	if err := ms.checkLimits(); err != nil {
+		return err
+	}
+
+	*ms.value, _ = ms.selection()
+	return nil
+}
+
+// Ask displays the multi-select prompt and waits for the user to confirm a selection.
+func (ms *MultiSelect[T]) Ask() error {
+	return ms.AskContext(context.Background())
+}
+
+// AskContext is like Ask but stops waiting once ctx is done, erasing the
+// partially drawn list and returning an error that wraps ctx.Err().
+func (ms *MultiSelect[T]) AskContext(ctx context.Context) error {
+	if ms.title.val == "" && ms.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if ms.value == nil {
+		return ErrNoValue
+	}
+
+	if len(ms.options) == 0 {
+		return ErrNoSelectOptions
+	}
+
+	if ms.min < 0 || ms.max < 0 || ms.min > len(ms.options) || ms.max > 0 && ms.min > ms.max {
+		return ErrInvalidLimits
+	}
+
+	ms.initSelected()
+
+	if !tui.IsInteractive(ms.term) || ms.session.Accessible() {
+		return ms.askLines(ctx)
+	}
+
+	defer tui.Guard(ms.term)()
+	defer func() {
+		fmt.Fprint(ms.term, ansi.ShowCursor)
+	}()
+	reader := tui.NewKeyReader(ms.term)
+	defer reader.Close()
+
+	ms.screen = tui.NewRenderer(ms.term)
+	fmt.Fprint(ms.term, ansi.HideCursor)
+	ms.renderOptions()
+
+	for {
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				ms.screen.Erase()
+			}
+			return err
+		}
+
+		switch ev.Key {
+		case keys.Resize:
+			ms.renderOptions()
+			continue
+		case keys.Resume:
+			ms.resume()
+			continue
+		}
+		ms.errMsg = "" // Any key press dismisses the previous error
+
+		if pos, ok := tui.MoveListCursor(ev, ms.cursorPos, len(ms.options), ms.pageSize()); ok {
+			ms.cursorPos = pos
+			ms.renderOptions()
+			continue
+		}
+
+		switch {
+		case ev == keys.Ctrl('c'):
+			return ErrUserAborted
+		case ev.Key == keys.Enter:
+			if err := ms.checkLimits(); err != nil {
+				ms.errMsg = err.Error()
+				ms.renderOptions()
+				continue
+			}
+
+			values, labels := ms.selection()
+			*ms.value = values
+			ms.screen.Finish(ms.icon.Get() + ms.title.Get() + " " + ms.getAnswerFunc(labels))
+			return nil
+		case ev == keys.Char(' '):
+			ms.toggle()
+		case ev == keys.Char('a'):
+			ms.selectAll()
+		case ev == keys.Char('i'):
+			ms.invert()
+		}
+
+		ms.renderOptions()
+	}
+}
+
+// askLines lists the numbered options and reads a comma separated list of
+// choices a line at a time for non-interactive terminals and accessible
+// mode. An empty line keeps the options that are already selected.
+func (ms *MultiSelect[T]) askLines(ctx context.Context) error {
+	labels := make([]string, len(ms.options))
+	for i, opt := range ms.options {
+		labels[i] = opt.Key
+	}
+
+	fmt.Fprintf(ms.term, "%s%s\n", ms.icon.Get(), ms.title.Get())
+	renderNumberedOptions(ms.term, labels, func(i int) string {
+		if ms.selected[i] {
+			return ms.checked
+		}
+		return ms.unchecked
+	})
+
+	lines := newLineAsker(ms.term, ms.session)
+	preselected := ms.selected
+	prompt := "Enter numbers or names separated by commas: "
+	for {
+		text, err := lines.read(ctx, prompt)
+		if err != nil {
+			return err
+		}
+
+		if err := ms.chooseLines(text, labels, preselected); err != nil {
+			lines.reject(text, err)
+			continue
+		}
+
+		values, answer := ms.selection()
+		*ms.value = values
+		lines.accept(text, ms.getAnswerFunc(answer), answer)
+		return nil
+	}
+}
+
+// chooseLines selects the options named in a comma separated list of
+// choices, checking the selection limits.
+func (ms *MultiSelect[T]) chooseLines(text string, labels []string, preselected []bool) error {
+	selected := make([]bool, len(ms.options))
+	if strings.TrimSpace(text) == "" {
+		copy(selected, preselected)
+	} else {
+		for _, choice := range strings.Split(text, ",") {
+			idx, err := chooseOption(choice, labels)
+			if err != nil {
+				return err
+			}
+			selected[idx] = true
+		}
+	}
+
+	ms.selected = selected
+	return ms.checkLimits()
+}
+
+// checkLimits reports whether the number of selected options is outside the
+// minimum and maximum.
+func (ms *MultiSelect[T]) checkLimits() error {
+	switch n := ms.count(); {
+	case n < ms.min:
+		return fmt.Errorf("select at least %d %s", ms.min, pluralOptions(ms.min))
+	case ms.max > 0 && n > ms.max:
+		return fmt.Errorf("select at most %d %s", ms.max, pluralOptions(ms.max))
+	}
+	return nil
+}
+
+// resume draws the prompt again in full after the process was continued,
+// as the shell may have written over it.
+func (ms *MultiSelect[T]) resume() {
+	ms.screen.Reset()
+	fmt.Fprint(ms.term, ansi.HideCursor)
+	ms.renderOptions()
+}
+
+// pageSize returns the number of options visible at once.
+func (ms *MultiSelect[T]) pageSize() int {
+	return tui.TerminalHeight(ms.term) - 4 // Space for prompt, error line and cursor movement
+}
+
+// renderOptions displays the options with their selection markers and any error line.
+func (ms *MultiSelect[T]) renderOptions() {
+	termHeight := ms.pageSize()
+	selectCursor := ms.cursor.Get()
+	padding := strings.Repeat(" ", tui.StringWidth(selectCursor))
+
+	// Show fewer options when long ones wrap, so the prompt still fits on screen
+	width := tui.TerminalWidth(ms.term)
+	termHeight -= tui.ScreenRows([]string{ms.icon.Get() + ms.title.Get()}, width) - 1
+	if ms.errMsg != "" {
+		termHeight -= tui.ScreenRows([]string{tui.FormatError(ms.errMsg)}, width) - 1
+	}
+
+	var end int
+	ms.scrollOffset, end = tui.ScrollWindow(ms.cursorPos, ms.scrollOffset, len(ms.options), termHeight)
+	ms.scrollOffset, end = tui.FitWindow(ms.cursorPos, ms.scrollOffset, end, termHeight, func(i int) int {
+		return tui.ScreenRows([]string{padding + ms.marker(i) + ms.options[i].Key}, width)
+	})
+
+	lines := make([]string, 0, end-ms.scrollOffset+2)
+	lines = append(lines, ms.icon.Get()+ms.title.Get())
+	for i := ms.scrollOffset; i < end; i++ {
+		marker := ms.marker(i)
+
+		if i == ms.cursorPos {
+			lines = append(lines, ms.getSelectFunc(selectCursor)+ms.getSelectFunc(marker+ms.options[i].Key))
+		} else {
+			lines = append(lines, padding+marker+ms.options[i].Key)
+		}
+	}
+
+	if ms.errMsg != "" {
+		lines = append(lines, tui.FormatError(ms.errMsg))
+	}
+
+	ms.screen.Draw(lines)
+}
+
+// marker returns the marker showing whether option i is selected.
+func (ms *MultiSelect[T]) marker(i int) string {
+	if ms.selected[i] {
+		return ms.checked
+	}
+	return ms.unchecked
+}
+
+// pluralOptions returns the correctly pluralized noun for n options.
+func pluralOptions(n int) string {
+	if n == 1 {
+		return "option"
+	}
+	return "options"
+}
@@ -0,0 +1,316 @@
+package pardon
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+func newTestMultiSelect(result *[]string) *MultiSelect[string] {
+	options := []Option[string]{
+		{Key: "Option 1", Value: "value1"},
+		{Key: "Option 2", Value: "value2"},
+		{Key: "Option 3", Value: "value3"},
+	}
+
+	ms := NewMultiSelect[string]().Options(options...).Value(result).Title("Test")
+	ms.initSelected()
+	return ms
+}
+
+func TestMultiSelectCreation(t *testing.T) {
+	var result []string
+	ms := NewMultiSelect[string]().Value(&result)
+
+	if ms == nil {
+		t.Fatal("NewMultiSelect returned nil")
+	}
+
+	if ms.value != &result {
+		t.Error("MultiSelect value pointer not properly set")
+	}
+
+	if ms.checked != "[x] " || ms.unchecked != "[ ] " {
+		t.Errorf("Default markers = %q, %q; want %q, %q", ms.checked, ms.unchecked, "[x] ", "[ ] ")
+	}
+
+	if ms.min != 0 || ms.max != 0 {
+		t.Errorf("Default limits = %d, %d; want 0, 0", ms.min, ms.max)
+	}
+}
+
+func TestMultiSelectBuilders(t *testing.T) {
+	var result []int
+	ms := NewMultiSelect[int]().
+		Title("Pick some").
+		Cursor("→ ").
+		Markers("◉ ", "○ ").
+		Min(1).
+		Max(2).
+		Value(&result)
+
+	if ms.title.val != "Pick some" {
+		t.Errorf("Title() = %q; want %q", ms.title.val, "Pick some")
+	}
+
+	if ms.cursor.val != "→ " {
+		t.Errorf("Cursor() = %q; want %q", ms.cursor.val, "→ ")
+	}
+
+	if ms.checked != "◉ " || ms.unchecked != "○ " {
+		t.Errorf("Markers() = %q, %q; want %q, %q", ms.checked, ms.unchecked, "◉ ", "○ ")
+	}
+
+	if ms.min != 1 || ms.max != 2 {
+		t.Errorf("Min()/Max() = %d, %d; want 1, 2", ms.min, ms.max)
+	}
+}
+
+func TestMultiSelectValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt func() *MultiSelect[string]
+		want   error
+	}{
+		{
+			name: "no title",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Value(&result)
+			},
+			want: ErrNoTitle,
+		},
+		{
+			name: "no value",
+			prompt: func() *MultiSelect[string] {
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Title("Test")
+			},
+			want: ErrNoValue,
+		},
+		{
+			name: "no options",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Value(&result).Title("Test")
+			},
+			want: ErrNoSelectOptions,
+		},
+		{
+			name: "min greater than max",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Value(&result).Title("Test").Min(3).Max(2)
+			},
+			want: ErrInvalidLimits,
+		},
+		{
+			name: "min greater than options",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Value(&result).Title("Test").Min(2)
+			},
+			want: ErrInvalidLimits,
+		},
+		{
+			name: "negative min",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Value(&result).Title("Test").Min(-1)
+			},
+			want: ErrInvalidLimits,
+		},
+		{
+			name: "negative max",
+			prompt: func() *MultiSelect[string] {
+				var result []string
+				return NewMultiSelect[string]().Options(NewOption("a", "a")).Value(&result).Title("Test").Max(-1)
+			},
+			want: ErrInvalidLimits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.prompt().Ask(); err != tt.want {
+				t.Errorf("Ask() = %v; want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiSelectInitialSelection(t *testing.T) {
+	result := []string{"value3", "missing"}
+	ms := newTestMultiSelect(&result)
+
+	want := []bool{false, false, true}
+	if !reflect.DeepEqual(ms.selected, want) {
+		t.Errorf("selected = %v; want %v", ms.selected, want)
+	}
+}
+
+func TestMultiSelectToggle(t *testing.T) {
+	var result []string
+	ms := newTestMultiSelect(&result).Max(1)
+
+	ms.toggle()
+	if !ms.selected[0] {
+		t.Error("toggle() did not select the option under the cursor")
+	}
+
+	ms.cursorPos = 1
+	ms.toggle()
+	if ms.selected[1] {
+		t.Error("toggle() selected an option beyond the maximum")
+	}
+	if ms.errMsg == "" {
+		t.Error("toggle() beyond the maximum should set an error")
+	}
+
+	ms.errMsg = ""
+	ms.cursorPos = 0
+	ms.toggle()
+	if ms.selected[0] {
+		t.Error("toggle() did not deselect the option under the cursor")
+	}
+	if ms.errMsg != "" {
+		t.Errorf("toggle() set unexpected error %q", ms.errMsg)
+	}
+}
+
+func TestMultiSelectSelectAll(t *testing.T) {
+	var result []string
+	ms := newTestMultiSelect(&result)
+
+	ms.selectAll()
+	if ms.count() != 3 {
+		t.Errorf("selectAll() selected %d options; want 3", ms.count())
+	}
+
+	ms.selectAll()
+	if ms.count() != 0 {
+		t.Errorf("selectAll() on a full selection left %d options; want 0", ms.count())
+	}
+
+	ms.Max(2).selectAll()
+	if ms.count() != 0 || ms.errMsg == "" {
+		t.Error("selectAll() beyond the maximum should leave the selection unchanged and set an error")
+	}
+}
+
+func TestMultiSelectInvert(t *testing.T) {
+	result := []string{"value1"}
+	ms := newTestMultiSelect(&result)
+
+	ms.invert()
+	want := []bool{false, true, true}
+	if !reflect.DeepEqual(ms.selected, want) {
+		t.Errorf("invert() selected = %v; want %v", ms.selected, want)
+	}
+
+	ms.Max(1).invert()
+	want = []bool{true, false, false}
+	if !reflect.DeepEqual(ms.selected, want) || ms.errMsg != "" {
+		t.Errorf("invert() within the maximum = %v (err %q); want %v", ms.selected, ms.errMsg, want)
+	}
+
+	ms.invert()
+	if !reflect.DeepEqual(ms.selected, want) || ms.errMsg == "" {
+		t.Error("invert() beyond the maximum should leave the selection unchanged and set an error")
+	}
+}
+
+func TestMultiSelectAnswer(t *testing.T) {
+	result := []string{"value1", "value3"}
+	ms := newTestMultiSelect(&result)
+
+	values, labels := ms.selection()
+
+	if !reflect.DeepEqual(values, []string{"value1", "value3"}) {
+		t.Errorf("selection() values = %v; want %v", values, []string{"value1", "value3"})
+	}
+
+	if labels != "Option 1, Option 3" {
+		t.Errorf("selection() labels = %q; want %q", labels, "Option 1, Option 3")
+	}
+}
+
+func TestMultiSelectScreen(t *testing.T) {
+	var result []string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type(" ").Press(keys.Down, keys.Down).Type(" ")
+
+	newTestMultiSelect(&result).Icon("").Terminal(term).Ask()
+
+	want := "Test\n  [x] Option 1\n  [ ] Option 2\n> [x] Option 3"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+
+}
+
+func TestMultiSelectScreenAnswer(t *testing.T) {
+	var result []string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type(" ").Press(keys.Up).Type(" ").Press(keys.Enter)
+
+	if err := newTestMultiSelect(&result).Icon("").Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if got, want := term.Screen(), "Test Option 1, Option 3"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestMultiSelectScreenMax(t *testing.T) {
+	result := []string{"value1", "value2", "value3"}
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Enter)
+
+	// Input runs out with the limit reported
+	err := newTestMultiSelect(&result).Icon("").Max(1).Terminal(term).Ask()
+	if err == nil {
+		t.Fatalf("Ask() accepted %v over the maximum", result)
+	}
+	if !term.Contains("select at most 1 option") {
+		t.Errorf("Screen() = %q; want the maximum reported", term.Screen())
+	}
+
+	// Unchecking the extra options makes the selection acceptable
+	term.Type(" ").Press(keys.Down).Type(" ").Press(keys.Enter)
+	if err := newTestMultiSelect(&result).Icon("").Max(1).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, []string{"value3"}) {
+		t.Errorf("Ask() value = %v; want [value3]", result)
+	}
+}
+
+func TestMultiSelectAccessible(t *testing.T) {
+	var result []int
+	term := pardontest.NewTerminal(50, 10)
+	term.Type("1, green").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	err := NewMultiSelect[int]().
+		Icon("").
+		Title("Colors:").
+		Options(NewOption("Red", 1), NewOption("Blue", 2), NewOption("Green", 3)).
+		Value(&result).
+		Session(session).
+		Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if len(result) != 2 || result[0] != 1 || result[1] != 3 {
+		t.Errorf("Ask() value = %v; want [1 3]", result)
+	}
+	if !term.Contains("Selected: Red, Green") {
+		t.Errorf("Screen() = %q; want the choice announced", term.Screen())
+	}
+}
@@ -0,0 +1,395 @@
+package pardon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Numeric is the set of types a Number prompt can read.
+type Numeric interface {
+	int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64
+}
+
+// Number represents a numeric input prompt. Only characters that can make
+// up a number are accepted, and Up and Down step the value.
+type Number[T Numeric] struct {
+	name       string
+	session    *Session
+	icon       eval[string]
+	title      eval[string]
+	value      *T
+	min, max   *T
+	step       T
+	answerFn   func(string) string
+	validateFn func(T) error
+	tui        *tui.InputPrompt[string]
+}
+
+// NewNumber creates a new Number prompt instance stepping by 1.
+func NewNumber[T Numeric]() *Number[T] {
+	n := &Number[T]{
+		icon:  eval[string]{val: Icons.QuestionMark},
+		title: eval[string]{val: ""},
+		step:  1,
+		tui:   tui.NewStringPrompt(),
+	}
+	n.tui.Accept(n.acceptRune).KeyFunc(n.stepKey).Validate(func(s string) error {
+		_, err := n.parse(s)
+		return err
+	})
+	n.setSession(defaultSession)
+	return n
+}
+
+// Terminal sets the terminal the number prompt reads from and renders to.
+func (n *Number[T]) Terminal(t Terminal) *Number[T] {
+	n.setTerminal(t)
+	return n
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (n *Number[T]) setTerminal(t Terminal) {
+	n.tui.Terminal(t)
+}
+
+// Session makes the number prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (n *Number[T]) Session(s *Session) *Number[T] {
+	n.setSession(s)
+	return n
+}
+
+// setSession moves the prompt to s.
+func (n *Number[T]) setSession(s *Session) {
+	d := s.defaults()
+	n.session = s
+	n.tui.Terminal(s.Terminal())
+	n.icon.defaultFn = d.iconFn
+	n.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the number in an AnswerSource.
+func (n *Number[T]) Name(name string) *Number[T] {
+	n.name = name
+	return n
+}
+
+// Title sets the prompt text.
+func (n *Number[T]) Title(title string) *Number[T] {
+	n.title.val = title
+	n.title.fn = nil
+	return n
+}
+
+// TitleFunc sets a dynamic title function.
+func (n *Number[T]) TitleFunc(fn func(string) string) *Number[T] {
+	n.title.fn = fn
+	return n
+}
+
+// Icon sets the prompt icon.
+func (n *Number[T]) Icon(s string) *Number[T] {
+	n.icon.val = s
+	n.icon.fn = nil
+	return n
+}
+
+// IconFunc sets a dynamic icon function.
+func (n *Number[T]) IconFunc(fn func(string) string) *Number[T] {
+	n.icon.fn = fn
+	return n
+}
+
+// Value sets the number to fill in, which also holds the default.
+func (n *Number[T]) Value(value *T) *Number[T] {
+	n.value = value
+	return n
+}
+
+// Min sets the smallest number accepted. It can't be greater than Max.
+func (n *Number[T]) Min(v T) *Number[T] {
+	n.min = &v
+	return n
+}
+
+// Max sets the largest number accepted.
+func (n *Number[T]) Max(v T) *Number[T] {
+	n.max = &v
+	return n
+}
+
+// Step sets how much Up and Down change the number by. Steps that are not
+// positive are ignored.
+func (n *Number[T]) Step(v T) *Number[T] {
+	if v > 0 {
+		n.step = v
+	}
+	return n
+}
+
+// AnswerFunc sets a function to transform the final answer.
+func (n *Number[T]) AnswerFunc(fn func(string) string) *Number[T] {
+	n.answerFn = fn
+	return n
+}
+
+// Validate sets a check run on numbers within range.
+func (n *Number[T]) Validate(fn func(T) error) *Number[T] {
+	n.validateFn = fn
+	return n
+}
+
+// setAnswerFunc configures the answer transformation priority:
+// prompt-specific, session default, or identity function.
+func (n *Number[T]) setAnswerFunc() {
+	if n.answerFn != nil {
+		n.tui.AnswerFunc(n.answerFn)
+		return
+	}
+
+	if fn := n.session.defaults().answerFn; fn != nil {
+		n.tui.AnswerFunc(fn)
+		return
+	}
+
+	n.tui.AnswerFunc(func(input string) string { return input })
+}
+
+// errNotNumber and errNotWholeNumber are shown when the input can't be parsed.
+var (
+	errNotNumber      = errors.New("enter a number")
+	errNotWholeNumber = errors.New("enter a whole number")
+)
+
+// numberKind reports whether T is a floating point and a signed type.
+func numberKind[T Numeric]() (float, signed bool) {
+	switch any(T(0)).(type) {
+	case float32, float64:
+		return true, true
+	case int, int8, int16, int32, int64:
+		return false, true
+	}
+	return false, false
+}
+
+// parse reads s as a number, checking it is within range and valid.
+func (n *Number[T]) parse(s string) (T, error) {
+	v, err := parseNumber[T](s)
+	if err != nil {
+		if float, _ := numberKind[T](); float {
+			return 0, errNotNumber
+		}
+		return 0, errNotWholeNumber
+	}
+
+	switch {
+	case n.min != nil && n.max != nil && (v < *n.min || v > *n.max):
+		return 0, fmt.Errorf("enter a number from %s to %s", formatNumber(*n.min), formatNumber(*n.max))
+	case n.min != nil && v < *n.min:
+		return 0, fmt.Errorf("enter a number no less than %s", formatNumber(*n.min))
+	case n.max != nil && v > *n.max:
+		return 0, fmt.Errorf("enter a number no greater than %s", formatNumber(*n.max))
+	}
+
+	if n.validateFn != nil {
+		if err := n.validateFn(v); err != nil {
+			return 0, err
+		}
+	}
+	return v, nil
+}
+
+// acceptRune reports whether r can be typed into a number of type T.
+func (n *Number[T]) acceptRune(r rune) bool {
+	float, signed := numberKind[T]()
+	switch {
+	case r >= '0' && r <= '9':
+		return true
+	case r == '-':
+		return signed
+	case r == '.', r == 'e', r == 'E', r == '+':
+		return float
+	}
+	return false
+}
+
+// stepKey steps the number in input up or down by the step, keeping it in
+// range. Input that isn't a number starts from the minimum, or zero.
+func (n *Number[T]) stepKey(ev keys.Event, input string) (string, bool) {
+	if ev.Key != keys.Up && ev.Key != keys.Down {
+		return "", false
+	}
+
+	v, err := parseNumber[T](input)
+	switch {
+	case err != nil && n.min != nil:
+		v = *n.min
+	case err != nil:
+		v = 0
+	case ev.Key == keys.Up:
+		// Stop at the limit of T instead of wrapping around
+		if next := n.round(v, v+n.step); next > v {
+			v = next
+		}
+	default:
+		if next := n.round(v, v-n.step); next < v {
+			v = next
+		}
+	}
+
+	if n.min != nil && v < *n.min {
+		v = *n.min
+	}
+	if n.max != nil && v > *n.max {
+		v = *n.max
+	}
+	return formatNumber(v), true
+}
+
+// round rounds next, stepped to from v, to the decimal places of v or the
+// step, whichever has more, so stepping by 0.1 three times gives 0.3 rather
+// than the binary error added up along the way. Integers are returned as is.
+func (n *Number[T]) round(v, next T) T {
+	if float, _ := numberKind[T](); !float {
+		return next
+	}
+
+	places := max(decimalPlaces(v), decimalPlaces(n.step))
+	s := strconv.FormatFloat(float64(next), 'f', places, bitSize[T]())
+	if rounded, err := strconv.ParseFloat(s, bitSize[T]()); err == nil {
+		return T(rounded)
+	}
+	return next
+}
+
+// decimalPlaces returns the number of digits after the decimal point when v
+// is formatted.
+func decimalPlaces[T Numeric](v T) int {
+	s := formatNumber(v)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// parseNumber parses s as a number of type T. Floating point numbers must
+// be finite.
+func parseNumber[T Numeric](s string) (T, error) {
+	switch any(T(0)).(type) {
+	case float32, float64:
+		f, err := strconv.ParseFloat(s, bitSize[T]())
+		if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			err = strconv.ErrSyntax
+		}
+		return T(f), err
+	case int, int8, int16, int32, int64:
+		i, err := strconv.ParseInt(s, 10, bitSize[T]())
+		return T(i), err
+	default:
+		u, err := strconv.ParseUint(s, 10, bitSize[T]())
+		return T(u), err
+	}
+}
+
+// bitSize returns the size of T in bits.
+func bitSize[T Numeric]() int {
+	switch any(T(0)).(type) {
+	case int8, uint8:
+		return 8
+	case int16, uint16:
+		return 16
+	case int32, uint32, float32:
+		return 32
+	case int, uint:
+		return strconv.IntSize
+	}
+	return 64
+}
+
+// formatNumber formats v the shortest way it parses back from.
+func formatNumber[T Numeric](v T) string {
+	switch v := any(v).(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// promptName returns the name of the number prompt.
+func (n *Number[T]) promptName() string {
+	return n.name
+}
+
+// answer sets the value from a scripted number or numeric string after
+// checking it.
+func (n *Number[T]) answer(v any) error {
+	if n.value == nil {
+		return ErrNoValue
+	}
+
+	if n.min != nil && n.max != nil && *n.min > *n.max {
+		return ErrInvalidRange
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	num, err := n.parse(s)
+	if err != nil {
+		return err
+	}
+
+	*n.value = num
+	return nil
+}
+
+// Ask displays the number prompt and waits for input.
+func (n *Number[T]) Ask() error {
+	return n.AskContext(context.Background())
+}
+
+// AskContext is like Ask but gives up when ctx is done, erasing the prompt
+// and returning an error wrapping ctx.Err().
+func (n *Number[T]) AskContext(ctx context.Context) error {
+	if n.title.val == "" && n.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if n.value == nil {
+		return ErrNoValue
+	}
+
+	if n.min != nil && n.max != nil && *n.min > *n.max {
+		return ErrInvalidRange
+	}
+
+	question := fmt.Sprintf("%s%s ", n.icon.Get(), n.title.Get())
+	n.setAnswerFunc()
+	n.tui.Accessible(n.session.Accessible())
+
+	text := formatNumber(*n.value)
+	if err := n.tui.DisplayContext(ctx, question, &text); err != nil {
+		return err
+	}
+
+	num, err := n.parse(text)
+	if err != nil {
+		return err
+	}
+
+	*n.value = num
+	return nil
+}
@@ -0,0 +1,209 @@
+package pardon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+func TestNumberCreation(t *testing.T) {
+	number := NewNumber[int]()
+
+	if number.value != nil {
+		t.Error("Number value should be nil initially")
+	}
+	if number.step != 1 {
+		t.Errorf("step = %d; want 1", number.step)
+	}
+	if number.min != nil || number.max != nil {
+		t.Error("Number should have no bounds initially")
+	}
+
+	var result int
+	if err := number.Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewNumber[int]().Title("N?").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+	if err := NewNumber[int]().Title("N?").Min(10).Max(1).Value(&result).Ask(); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrInvalidRange)
+	}
+	if err := NewNumber[int]().Min(10).Max(1).Value(&result).answer(5); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("answer(5) error = %v; want %v", err, ErrInvalidRange)
+	}
+}
+
+func TestNumberParse(t *testing.T) {
+	even := func(v int) error {
+		if v%2 != 0 {
+			return errors.New("enter an even number")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		prompt  *Number[int]
+		input   string
+		want    int
+		wantErr string
+	}{
+		{"whole number", NewNumber[int](), "42", 42, ""},
+		{"negative", NewNumber[int](), "-7", -7, ""},
+		{"not a number", NewNumber[int](), "4.2", 0, "enter a whole number"},
+		{"empty", NewNumber[int](), "", 0, "enter a whole number"},
+		{"within range", NewNumber[int]().Min(1).Max(10), "10", 10, ""},
+		{"outside range", NewNumber[int]().Min(1).Max(10), "11", 0, "enter a number from 1 to 10"},
+		{"below min", NewNumber[int]().Min(1), "0", 0, "enter a number no less than 1"},
+		{"above max", NewNumber[int]().Max(5), "6", 0, "enter a number no greater than 5"},
+		{"validate", NewNumber[int]().Validate(even), "3", 0, "enter an even number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.prompt.parse(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("parse(%q) error = %v; want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parse(%q) = %d, %v; want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberTypes(t *testing.T) {
+	if _, err := NewNumber[uint]().parse("-1"); err == nil {
+		t.Error("uint parse(\"-1\") error = nil; want an error")
+	}
+	if _, err := NewNumber[int8]().parse("200"); err == nil {
+		t.Error("int8 parse(\"200\") error = nil; want an error")
+	}
+	if _, err := NewNumber[float64]().parse("NaN"); err == nil {
+		t.Error("float64 parse(\"NaN\") error = nil; want an error")
+	}
+	if got, err := NewNumber[float32]().parse("2.5"); err != nil || got != 2.5 {
+		t.Errorf("float32 parse(\"2.5\") = %v, %v; want 2.5", got, err)
+	}
+
+	if NewNumber[uint]().acceptRune('-') {
+		t.Error("uint accepts '-'; want only digits")
+	}
+	if !NewNumber[float64]().acceptRune('.') || NewNumber[int]().acceptRune('.') {
+		t.Error("'.' should be accepted by floating point numbers only")
+	}
+}
+
+func TestNumberScreen(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Backspace).Type("4x2").Press(keys.Enter)
+
+	if err := NewNumber[int]().Icon("").Title("Age?").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != 42 {
+		t.Errorf("Ask() value = %d; want 42", result)
+	}
+	if got, want := term.Screen(), "Age? 42"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestNumberStep(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  int
+		number func(*Number[int]) *Number[int]
+		keys   []keys.Key
+		want   int
+	}{
+		{"up", 5, func(n *Number[int]) *Number[int] { return n }, []keys.Key{keys.Up, keys.Up}, 7},
+		{"down", 5, func(n *Number[int]) *Number[int] { return n }, []keys.Key{keys.Down}, 4},
+		{"step", 5, func(n *Number[int]) *Number[int] { return n.Step(5) }, []keys.Key{keys.Up}, 10},
+		{"stops at max", 5, func(n *Number[int]) *Number[int] { return n.Max(6) }, []keys.Key{keys.Up, keys.Up}, 6},
+		{"stops at min", 1, func(n *Number[int]) *Number[int] { return n.Min(0) }, []keys.Key{keys.Down, keys.Down}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value
+			term := pardontest.NewTerminal(40, 10)
+			term.Press(tt.keys...).Press(keys.Enter)
+
+			if err := tt.number(NewNumber[int]().Title("N?").Value(&result).Terminal(term)).Ask(); err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Ask() value = %d; want %d", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberStepFloat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		step  float64
+		keys  []keys.Key
+		want  string
+	}{
+		{"up by tenths", 0, 0.1, []keys.Key{keys.Up, keys.Up, keys.Up}, "0.3"},
+		{"down by tenths", 1, 0.1, []keys.Key{keys.Down, keys.Down, keys.Down}, "0.7"},
+		{"keeps places of value", 0.25, 0.1, []keys.Key{keys.Up, keys.Up}, "0.45"},
+		{"whole step", 1.5, 1, []keys.Key{keys.Up}, "2.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value
+			term := pardontest.NewTerminal(40, 10)
+			term.Press(tt.keys...)
+
+			// Input runs out with the stepped number still shown
+			NewNumber[float64]().Icon("").Title("N?").Step(tt.step).Value(&result).Terminal(term).Ask()
+
+			if got, want := term.Screen(), "N? "+tt.want; got != want {
+				t.Errorf("Screen() = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNumberRangeError(t *testing.T) {
+	result := 5
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Backspace).Type("20").Press(keys.Enter)
+
+	NewNumber[int]().Icon("").Title("Pick:").Min(1).Max(10).Value(&result).Terminal(term).Ask()
+
+	if result != 5 {
+		t.Errorf("Ask() value = %d; want 5 to be kept", result)
+	}
+	if want := "Pick: 20\n* enter a number from 1 to 10"; term.Screen() != want {
+		t.Errorf("Screen() = %q; want %q", term.Screen(), want)
+	}
+}
+
+func TestNumberAnswer(t *testing.T) {
+	var result float64
+	number := NewNumber[float64]().Max(10).Value(&result)
+
+	if err := number.answer(2.5); err != nil || result != 2.5 {
+		t.Errorf("answer(2.5) = %v, value %v; want 2.5", err, result)
+	}
+	if err := number.answer("7"); err != nil || result != 7 {
+		t.Errorf("answer(\"7\") = %v, value %v; want 7", err, result)
+	}
+	if err := number.answer(11); err == nil {
+		t.Error("answer(11) error = nil; want a range error")
+	}
+}
@@ -1,6 +1,7 @@
 package pardon
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/engmtcdrm/go-pardon/tui"
@@ -8,6 +9,8 @@ import (
 
 // Password represents a password input prompt that securely collects sensitive information.
 type Password struct {
+	name     string
+	session  *Session
 	icon     eval[string]
 	title    eval[string]
 	value    *[]byte
@@ -17,12 +20,47 @@ type Password struct {
 
 // NewPassword creates a new Password prompt instance.
 func NewPassword() *Password {
-	return &Password{
-		icon:  eval[string]{val: Icons.Password, defaultFn: defaultFuncs.iconFn},
-		title: eval[string]{val: "", defaultFn: defaultFuncs.titleFn},
+	p := &Password{
+		icon:  eval[string]{val: Icons.Password},
+		title: eval[string]{val: ""},
 		value: nil,
 		tui:   tui.NewPasswordPrompt(),
 	}
+	p.setSession(defaultSession)
+	return p
+}
+
+// Terminal sets the terminal the password prompt reads from and renders to.
+func (p *Password) Terminal(t Terminal) *Password {
+	p.setTerminal(t)
+	return p
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (p *Password) setTerminal(t Terminal) {
+	p.tui.Terminal(t)
+}
+
+// Session makes the password prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (p *Password) Session(s *Session) *Password {
+	p.setSession(s)
+	return p
+}
+
+// setSession moves the prompt to s.
+func (p *Password) setSession(s *Session) {
+	d := s.defaults()
+	p.session = s
+	p.tui.Terminal(s.Terminal())
+	p.icon.defaultFn = d.iconFn
+	p.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the password in an AnswerSource.
+func (p *Password) Name(name string) *Password {
+	p.name = name
+	return p
 }
 
 // Title sets a static title for the password prompt.
@@ -70,25 +108,55 @@ func (p *Password) AnswerFunc(fn func(string) string) *Password {
 }
 
 // setAnswerFunc configures the answer transformation priority:
-// prompt-specific, global default, or identity function.
+// prompt-specific, session default, or identity function.
 func (p *Password) setAnswerFunc() {
 	if p.answerFn != nil {
 		p.tui.AnswerFunc(p.answerFn)
 		return
 	}
 
-	if defaultFuncs.answerFn != nil {
-		p.tui.AnswerFunc(defaultFuncs.answerFn)
+	if fn := p.session.defaults().answerFn; fn != nil {
+		p.tui.AnswerFunc(fn)
 		return
 	}
 
 	p.tui.AnswerFunc(func(input string) string { return input })
 }
 
+// promptName returns the name of the password prompt.
+func (p *Password) promptName() string {
+	return p.name
+}
+
+// answer sets the password from a scripted answer after validating it.
+func (p *Password) answer(v any) error {
+	if p.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	if err := p.tui.Check([]byte(s)); err != nil {
+		return err
+	}
+
+	*p.value = []byte(s)
+	return nil
+}
+
 // Ask displays the password prompt.
 func (p *Password) Ask() error {
+	return p.AskContext(context.Background())
+}
+
+// AskContext displays the password prompt until it is answered or ctx is done.
+func (p *Password) AskContext(ctx context.Context) error {
 	question := fmt.Sprintf("%s%s ", p.icon.Get(), p.title.Get())
 	p.setAnswerFunc()
+	p.tui.Accessible(p.session.Accessible())
 
-	return p.tui.Display(question, p.value)
+	return p.tui.DisplayContext(ctx, question, p.value)
 }
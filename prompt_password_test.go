@@ -2,6 +2,9 @@ package pardon
 
 import (
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
 )
 
 func TestPasswordCreation(t *testing.T) {
@@ -86,3 +89,28 @@ func TestPasswordWithValidate(t *testing.T) {
 		t.Error("Password with validation returned nil")
 	}
 }
+
+func TestPasswordAccessible(t *testing.T) {
+	var result []byte
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("secret").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	if err := NewPassword().Icon("").Title("Password:").Value(&result).Session(session).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if string(result) != "secret" {
+		t.Errorf("Ask() value = %q; want %q", result, "secret")
+	}
+
+	// The password is read without echo, even though lines are read
+	if got, want := term.Screen(), "Password:"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+	if term.IsRaw() {
+		t.Error("Terminal was left in raw mode")
+	}
+}
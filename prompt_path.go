@@ -0,0 +1,532 @@
+package pardon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// pathListSize is the number of completions listed below a Path prompt.
+const pathListSize = 5
+
+// Path represents a prompt for a file or directory path. Tab completes the
+// path against the filesystem, cycling through the candidates when there
+// is more than one, and a leading ~ stands for the home directory.
+type Path struct {
+	name       string
+	session    *Session
+	icon       eval[string]
+	title      eval[string]
+	value      *string
+	fsys       fs.FS
+	exts       []string
+	dirsOnly   bool
+	filesOnly  bool
+	mustExist  bool
+	answerFn   func(string) string
+	selectFn   func(string) string
+	validateFn func(string) error
+	tui        *tui.InputPrompt[string]
+
+	// Completion state, kept while the input is the last completion shown
+	candidates []string
+	index      int // Candidate shown, or -1 for their common prefix
+	offset     int // First candidate listed
+	completed  string
+}
+
+// NewPath creates a new Path prompt instance completing against the
+// operating system's filesystem.
+func NewPath() *Path {
+	p := &Path{
+		icon:  eval[string]{val: Icons.QuestionMark},
+		title: eval[string]{val: ""},
+		tui:   tui.NewStringPrompt(),
+	}
+	p.tui.KeyFunc(p.completeKey).Hint(p.hint).Validate(func(s string) error {
+		_, err := p.check(s)
+		return err
+	})
+	p.setSession(defaultSession)
+	return p
+}
+
+// Terminal sets the terminal the path prompt reads from and renders to.
+func (p *Path) Terminal(t Terminal) *Path {
+	p.setTerminal(t)
+	return p
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (p *Path) setTerminal(t Terminal) {
+	p.tui.Terminal(t)
+}
+
+// Session makes the path prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (p *Path) Session(s *Session) *Path {
+	p.setSession(s)
+	return p
+}
+
+// setSession moves the prompt to s.
+func (p *Path) setSession(s *Session) {
+	d := s.defaults()
+	p.session = s
+	p.tui.Terminal(s.Terminal())
+	p.icon.defaultFn = d.iconFn
+	p.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the path in an AnswerSource.
+func (p *Path) Name(name string) *Path {
+	p.name = name
+	return p
+}
+
+// Title sets the prompt text.
+func (p *Path) Title(title string) *Path {
+	p.title.val = title
+	p.title.fn = nil
+	return p
+}
+
+// TitleFunc sets a dynamic title function.
+func (p *Path) TitleFunc(fn func(string) string) *Path {
+	p.title.fn = fn
+	return p
+}
+
+// Icon sets the prompt icon.
+func (p *Path) Icon(s string) *Path {
+	p.icon.val = s
+	p.icon.fn = nil
+	return p
+}
+
+// IconFunc sets a dynamic icon function.
+func (p *Path) IconFunc(fn func(string) string) *Path {
+	p.icon.fn = fn
+	return p
+}
+
+// Value sets the path to fill in, which also holds the default. The path
+// stored has ~ expanded to the home directory.
+func (p *Path) Value(value *string) *Path {
+	p.value = value
+	return p
+}
+
+// Root makes the prompt complete and check paths within fsys instead of
+// the operating system's filesystem. Paths are then slash-separated and
+// relative to the root of fsys, and ~ is not expanded.
+func (p *Path) Root(fsys fs.FS) *Path {
+	p.fsys = fsys
+	return p
+}
+
+// Extensions limits files to those with one of the extensions, such as
+// ".yaml" and ".yml". Directories are still completed to reach them.
+func (p *Path) Extensions(exts ...string) *Path {
+	p.exts = p.exts[:0]
+	for _, ext := range exts {
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		p.exts = append(p.exts, ext)
+	}
+	return p
+}
+
+// DirsOnly limits the path to directories.
+func (p *Path) DirsOnly(on bool) *Path {
+	p.dirsOnly = on
+	if on {
+		p.filesOnly = false
+	}
+	return p
+}
+
+// FilesOnly limits the path to files. Directories are still completed to
+// reach them.
+func (p *Path) FilesOnly(on bool) *Path {
+	p.filesOnly = on
+	if on {
+		p.dirsOnly = false
+	}
+	return p
+}
+
+// MustExist rejects paths that don't exist.
+func (p *Path) MustExist(on bool) *Path {
+	p.mustExist = on
+	return p
+}
+
+// AnswerFunc sets a function to transform the final answer.
+func (p *Path) AnswerFunc(fn func(string) string) *Path {
+	p.answerFn = fn
+	return p
+}
+
+// SelectFunc sets a function to highlight the completion shown in the
+// list of candidates.
+func (p *Path) SelectFunc(fn func(string) string) *Path {
+	p.selectFn = fn
+	return p
+}
+
+// Validate sets a check run on paths that pass the prompt's own checks. It
+// is given the path with ~ expanded.
+func (p *Path) Validate(fn func(string) error) *Path {
+	p.validateFn = fn
+	return p
+}
+
+// setAnswerFunc configures the answer transformation priority:
+// prompt-specific, session default, or identity function.
+func (p *Path) setAnswerFunc() {
+	if p.answerFn != nil {
+		p.tui.AnswerFunc(p.answerFn)
+		return
+	}
+
+	if fn := p.session.defaults().answerFn; fn != nil {
+		p.tui.AnswerFunc(fn)
+		return
+	}
+
+	p.tui.AnswerFunc(func(input string) string { return input })
+}
+
+// getSelectFunc returns s highlighted as the current completion, in
+// reverse video when no select function has been configured.
+func (p *Path) getSelectFunc(s string) string {
+	if p.selectFn != nil {
+		return p.selectFn(s)
+	}
+
+	if fn := p.session.defaults().selectFn; fn != nil {
+		return fn(s)
+	}
+
+	return ansi.Reverse + s + ansi.ResetReverse
+}
+
+// separator returns the separator written after completed directories.
+func (p *Path) separator() string {
+	if p.fsys != nil {
+		return "/"
+	}
+	return string(filepath.Separator)
+}
+
+// expand replaces a leading ~ with the home directory, for paths on the
+// operating system's filesystem.
+func (p *Path) expand(name string) string {
+	if p.fsys != nil || name != "~" && !strings.HasPrefix(name, "~/") && !strings.HasPrefix(name, "~"+p.separator()) {
+		return name
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return home + name[1:]
+}
+
+// fsName returns name as a path within fsys.
+func fsName(name string) (string, error) {
+	name = path.Clean(strings.TrimPrefix(name, "/"))
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("%s is outside the root", name)
+	}
+	return name, nil
+}
+
+// stat returns information about the file at name.
+func (p *Path) stat(name string) (fs.FileInfo, error) {
+	if p.fsys == nil {
+		return os.Stat(name)
+	}
+
+	name, err := fsName(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(p.fsys, name)
+}
+
+// readDir returns the entries of the directory at name, sorted by name.
+func (p *Path) readDir(name string) ([]fs.DirEntry, error) {
+	if name == "" {
+		name = "."
+	}
+	if p.fsys == nil {
+		return os.ReadDir(name)
+	}
+
+	name, err := fsName(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadDir(p.fsys, name)
+}
+
+// hasExtension reports whether name has one of the allowed extensions.
+func (p *Path) hasExtension(name string) bool {
+	if len(p.exts) == 0 {
+		return true
+	}
+
+	for _, ext := range p.exts {
+		if strings.EqualFold(path.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// foldPathCase reports whether completion on the operating system's
+// filesystem ignores case, as its filesystems usually do on Windows and
+// macOS.
+var foldPathCase = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+// hasPrefix reports whether the file name begins with prefix, ignoring
+// case where the filesystem does.
+func (p *Path) hasPrefix(name, prefix string) bool {
+	if p.fsys != nil || !foldPathCase {
+		return strings.HasPrefix(name, prefix)
+	}
+
+	for _, r := range prefix {
+		c, size := utf8.DecodeRuneInString(name)
+		if size == 0 || !strings.EqualFold(string(c), string(r)) {
+			return false
+		}
+		name = name[size:]
+	}
+	return true
+}
+
+// complete returns the paths that input can be completed to, with a
+// separator after directories. Hidden files are left out unless input
+// names one.
+func (p *Path) complete(input string) []string {
+	dir, base := "", input
+	if i := strings.LastIndexAny(input, "/"+p.separator()); i >= 0 {
+		dir, base = input[:i+1], input[i+1:]
+	}
+	if dir == "" && base == "~" && p.fsys == nil {
+		return []string{"~" + p.separator()}
+	}
+
+	entries, err := p.readDir(p.expand(dir))
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !p.hasPrefix(name, base) || strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+
+		isDir := entry.IsDir()
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if info, err := p.stat(p.expand(dir + name)); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+
+		switch {
+		case isDir:
+			candidates = append(candidates, dir+name+p.separator())
+		case !p.dirsOnly && p.hasExtension(name):
+			candidates = append(candidates, dir+name)
+		}
+	}
+	return candidates
+}
+
+// commonPrefix returns the longest prefix shared by every candidate.
+func commonPrefix(candidates []string) string {
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// completeKey completes the input on Tab, first to the prefix its
+// candidates share, then cycling through them. Shift-Tab cycles back.
+func (p *Path) completeKey(ev keys.Event, input string) (string, bool) {
+	if ev.Key != keys.Tab {
+		return "", false
+	}
+
+	if input != p.completed || len(p.candidates) < 2 {
+		p.candidates = p.complete(input)
+		p.index, p.offset = -1, 0
+
+		switch len(p.candidates) {
+		case 0:
+			return "", false
+		case 1:
+			p.completed = p.candidates[0]
+			return p.completed, true
+		}
+
+		if prefix := commonPrefix(p.candidates); len(prefix) > len(input) {
+			p.completed = prefix
+			return prefix, true
+		}
+	}
+
+	switch {
+	case ev.Mod&keys.ModShift == 0:
+		p.index = (p.index + 1) % len(p.candidates)
+	case p.index <= 0:
+		p.index = len(p.candidates) - 1
+	default:
+		p.index--
+	}
+
+	p.completed = p.candidates[p.index]
+	return p.completed, true
+}
+
+// hint lists the candidates below the input while they are being cycled
+// through, highlighting the one shown.
+func (p *Path) hint(input string) []string {
+	if input != p.completed || len(p.candidates) < 2 {
+		return nil
+	}
+
+	var end int
+	p.offset, end = tui.ScrollWindow(max(p.index, 0), p.offset, len(p.candidates), pathListSize)
+
+	lines := make([]string, 0, end-p.offset+1)
+	for i := p.offset; i < end; i++ {
+		name := strings.TrimSuffix(p.candidates[i], p.separator())
+		if j := strings.LastIndexAny(name, "/"+p.separator()); j >= 0 {
+			name = name[j+1:]
+		}
+		if strings.HasSuffix(p.candidates[i], p.separator()) {
+			name += p.separator()
+		}
+
+		if i == p.index {
+			lines = append(lines, "  "+p.getSelectFunc(name))
+		} else {
+			lines = append(lines, "  "+name)
+		}
+	}
+	if more := len(p.candidates) - end; more > 0 {
+		lines = append(lines, fmt.Sprintf("  %s(%d more)%s", ansi.Dim, more, ansi.ResetIntensity))
+	}
+	return lines
+}
+
+// check expands input and checks the path it names against the prompt's
+// requirements.
+func (p *Path) check(input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		return "", errors.New("enter a path")
+	}
+	name := p.expand(input)
+
+	info, err := p.stat(name)
+	switch {
+	case err != nil && p.mustExist:
+		return "", fmt.Errorf("%s does not exist", input)
+	case err == nil && p.dirsOnly && !info.IsDir():
+		return "", fmt.Errorf("%s is not a directory", input)
+	case err == nil && p.filesOnly && info.IsDir():
+		return "", fmt.Errorf("%s is a directory", input)
+	case (err != nil || !info.IsDir()) && !p.dirsOnly && !p.hasExtension(name):
+		return "", fmt.Errorf("enter a path ending in %s", strings.Join(p.exts, " or "))
+	}
+
+	if p.validateFn != nil {
+		if err := p.validateFn(name); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// promptName returns the name of the path prompt.
+func (p *Path) promptName() string {
+	return p.name
+}
+
+// answer sets the value from a scripted path after checking it.
+func (p *Path) answer(v any) error {
+	if p.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	name, err := p.check(s)
+	if err != nil {
+		return err
+	}
+
+	*p.value = name
+	return nil
+}
+
+// Ask displays the path prompt and waits for input.
+func (p *Path) Ask() error {
+	return p.AskContext(context.Background())
+}
+
+// AskContext is like Ask but gives up when ctx is done, erasing the prompt
+// and returning an error wrapping ctx.Err().
+func (p *Path) AskContext(ctx context.Context) error {
+	if p.title.val == "" && p.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if p.value == nil {
+		return ErrNoValue
+	}
+
+	question := fmt.Sprintf("%s%s ", p.icon.Get(), p.title.Get())
+	p.setAnswerFunc()
+	p.tui.Accessible(p.session.Accessible())
+	p.candidates, p.completed = nil, ""
+
+	text := *p.value
+	if err := p.tui.DisplayContext(ctx, question, &text); err != nil {
+		return err
+	}
+
+	name, err := p.check(text)
+	if err != nil {
+		return err
+	}
+
+	*p.value = name
+	return nil
+}
@@ -0,0 +1,296 @@
+package pardon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+// testFS returns a small tree of files for completing paths against.
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"config.yaml":        {},
+		"config.yml":         {},
+		"docs/guide.md":      {},
+		"docs/notes.txt":     {},
+		"downloads/a.zip":    {},
+		"main.go":            {},
+		".hidden":            {},
+		"src/app/main.go":    {},
+		"src/lib/helpers.go": {},
+	}
+}
+
+func TestPathCreation(t *testing.T) {
+	var result string
+	if err := NewPath().Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewPath().Title("Path:").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+	if ext := NewPath().Extensions("yaml", ".yml").exts; len(ext) != 2 || ext[0] != ".yaml" || ext[1] != ".yml" {
+		t.Errorf("Extensions() = %q; want [.yaml .yml]", ext)
+	}
+	if p := NewPath().DirsOnly(true).FilesOnly(true); p.dirsOnly || !p.filesOnly {
+		t.Errorf("FilesOnly() after DirsOnly() dirsOnly = %v, filesOnly = %v", p.dirsOnly, p.filesOnly)
+	}
+}
+
+func TestPathComplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		input func(*pardontest.Terminal)
+		path  func(*Path) *Path
+		want  string
+	}{
+		{"single candidate", func(term *pardontest.Terminal) {
+			term.Type("ma").Press(keys.Tab)
+		}, nil, "main.go"},
+		{"directory gets separator", func(term *pardontest.Terminal) {
+			term.Type("sr").Press(keys.Tab)
+		}, nil, "src/"},
+		{"completes inside directory", func(term *pardontest.Terminal) {
+			term.Type("sr").Press(keys.Tab, keys.Tab)
+		}, nil, "src/app/"},
+		{"common prefix", func(term *pardontest.Terminal) {
+			term.Type("c").Press(keys.Tab)
+		}, nil, "config.y"},
+		{"cycles candidates", func(term *pardontest.Terminal) {
+			term.Type("c").Press(keys.Tab, keys.Tab, keys.Tab)
+		}, nil, "config.yml"},
+		{"cycles back to start", func(term *pardontest.Terminal) {
+			term.Type("c").Press(keys.Tab, keys.Tab, keys.Tab, keys.Tab)
+		}, nil, "config.yaml"},
+		{"shift tab cycles back", func(term *pardontest.Terminal) {
+			term.Type("c").Press(keys.Tab).Send(keys.Named(keys.Tab, keys.ModShift))
+		}, nil, "config.yml"},
+		{"hidden when named", func(term *pardontest.Terminal) {
+			term.Type(".h").Press(keys.Tab)
+		}, nil, ".hidden"},
+		{"no candidates", func(term *pardontest.Terminal) {
+			term.Type("zz").Press(keys.Tab)
+		}, nil, "zz"},
+		{"extensions", func(term *pardontest.Terminal) {
+			term.Type("docs/").Press(keys.Tab)
+		}, func(p *Path) *Path { return p.Extensions(".md") }, "docs/guide.md"},
+		{"dirs only", func(term *pardontest.Terminal) {
+			term.Type("d").Press(keys.Tab)
+		}, func(p *Path) *Path { return p.DirsOnly(true) }, "do"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result string
+			term := pardontest.NewTerminal(40, 10)
+			tt.input(term)
+			term.Press(keys.Enter)
+
+			p := NewPath().Icon("").Title("Path:").Root(testFS()).Value(&result).Terminal(term)
+			if tt.path != nil {
+				p = tt.path(p)
+			}
+			p.Ask()
+
+			// The prompt may reject the path, so check what was typed
+			if !term.Contains("Path: " + tt.want) {
+				t.Errorf("Screen() = %q; want input %q", term.Screen(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPathCandidates(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("c").Press(keys.Tab, keys.Tab)
+
+	// Input runs out with the list still shown
+	NewPath().Icon("").Title("File:").Root(testFS()).Value(&result).Terminal(term).Ask()
+
+	want := "File: config.yaml\n" +
+		"  config.yaml\n" +
+		"  config.yml"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestPathCandidatesScroll(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"a1", "a2", "a3", "a4", "a5", "a6", "a7"} {
+		fsys[name] = &fstest.MapFile{}
+	}
+
+	p := NewPath().Root(fsys)
+	p.completeKey(keys.Named(keys.Tab, 0), "a")
+	lines := p.hint(p.completed)
+
+	if len(lines) != pathListSize+1 {
+		t.Fatalf("hint() = %q; want %d candidates and a count", lines, pathListSize)
+	}
+	if got, want := lines[pathListSize], "  \x1b[2m(2 more)\x1b[22m"; got != want {
+		t.Errorf("hint() last line = %q; want %q", got, want)
+	}
+}
+
+func TestPathScreen(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("docs/gu").Press(keys.Tab, keys.Enter)
+
+	err := NewPath().Icon("").Title("File:").Root(testFS()).Value(&result).Terminal(term).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "docs/guide.md" {
+		t.Errorf("Ask() value = %q; want %q", result, "docs/guide.md")
+	}
+	if got, want := term.Screen(), "File: docs/guide.md"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestPathCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(*Path) *Path
+		input   string
+		wantErr string
+	}{
+		{"empty", nil, " ", "enter a path"},
+		{"new file", nil, "new.txt", ""},
+		{"must exist", func(p *Path) *Path { return p.MustExist(true) }, "new.txt", "new.txt does not exist"},
+		{"exists", func(p *Path) *Path { return p.MustExist(true) }, "docs/notes.txt", ""},
+		{"dirs only", func(p *Path) *Path { return p.DirsOnly(true) }, "main.go", "main.go is not a directory"},
+		{"new dir", func(p *Path) *Path { return p.DirsOnly(true) }, "build", ""},
+		{"files only", func(p *Path) *Path { return p.FilesOnly(true) }, "docs/", "docs/ is a directory"},
+		{"extension", func(p *Path) *Path { return p.Extensions(".yaml", ".yml") }, "main.go",
+			"enter a path ending in .yaml or .yml"},
+		{"extension case", func(p *Path) *Path { return p.Extensions(".yaml") }, "CONFIG.YAML", ""},
+		{"extension dir", func(p *Path) *Path { return p.Extensions(".yaml") }, "src", ""},
+		{"validate", func(p *Path) *Path {
+			return p.Validate(func(string) error { return errors.New("no") })
+		}, "main.go", "no"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPath().Root(testFS())
+			if tt.path != nil {
+				p = tt.path(p)
+			}
+
+			_, err := p.check(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("check(%q) error = %v", tt.input, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("check(%q) error = %v; want %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPathMustExistScreen(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("nope").Press(keys.Enter)
+
+	NewPath().Icon("").Title("File:").Root(testFS()).MustExist(true).Value(&result).Terminal(term).Ask()
+
+	if result != "" {
+		t.Errorf("Ask() value = %q; want it left empty", result)
+	}
+	if !term.Contains("* nope does not exist") {
+		t.Errorf("Screen() = %q; want the existence error", term.Screen())
+	}
+}
+
+func TestPathHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if err := os.Mkdir(filepath.Join(home, "projects"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var result string
+	term := pardontest.NewTerminal(60, 10)
+	term.Type("~").Press(keys.Tab).Type("pro").Press(keys.Tab, keys.Enter)
+
+	if err := NewPath().Icon("").Title("Dir:").DirsOnly(true).MustExist(true).Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	sep := string(filepath.Separator)
+	if want := home + sep + "projects" + sep; result != want {
+		t.Errorf("Ask() value = %q; want %q", result, want)
+	}
+	if want := "Dir: ~" + sep + "projects" + sep; term.Screen() != want {
+		t.Errorf("Screen() = %q; want %q", term.Screen(), want)
+	}
+}
+
+func TestPathCompleteCase(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	tests := []struct {
+		name string
+		fold bool
+		fsys fstest.MapFS
+		want string
+	}{
+		{"case ignored", true, nil, "README.md"},
+		{"case matters", false, nil, "rea"},
+		{"case matters in root", true, fstest.MapFS{"README.md": {}}, "rea"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(fold bool) { foldPathCase = fold }(foldPathCase)
+			foldPathCase = tt.fold
+
+			p := NewPath()
+			if tt.fsys != nil {
+				p = p.Root(tt.fsys)
+			}
+
+			got, ok := p.completeKey(keys.Named(keys.Tab), "rea")
+			if !ok {
+				got = "rea"
+			}
+			if got != tt.want {
+				t.Errorf("completeKey(Tab, %q) = %q; want %q", "rea", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathAnswer(t *testing.T) {
+	var result string
+	p := NewPath().Root(testFS()).MustExist(true).Value(&result)
+
+	if err := p.answer("main.go"); err != nil || result != "main.go" {
+		t.Errorf("answer(\"main.go\") = %v, value %q", err, result)
+	}
+	if err := p.answer("missing.go"); err == nil {
+		t.Error("answer(\"missing.go\") error = nil; want an existence error")
+	}
+	if err := p.answer(3); err == nil {
+		t.Error("answer(3) error = nil; want a type error")
+	}
+}
@@ -1,6 +1,7 @@
 package pardon
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/engmtcdrm/go-pardon/tui"
@@ -8,6 +9,8 @@ import (
 
 // Question represents a text input prompt for user questions.
 type Question struct {
+	name     string
+	session  *Session
 	icon     eval[string]
 	title    eval[string]
 	value    *string
@@ -17,12 +20,47 @@ type Question struct {
 
 // NewQuestion creates a new Question prompt instance.
 func NewQuestion() *Question {
-	return &Question{
-		icon:  eval[string]{val: Icons.QuestionMark, defaultFn: defaultFuncs.iconFn},
-		title: eval[string]{val: "", defaultFn: defaultFuncs.titleFn},
+	q := &Question{
+		icon:  eval[string]{val: Icons.QuestionMark},
+		title: eval[string]{val: ""},
 		value: nil,
 		tui:   tui.NewStringPrompt(),
 	}
+	q.setSession(defaultSession)
+	return q
+}
+
+// Terminal sets the terminal the question prompt reads from and renders to.
+func (q *Question) Terminal(t Terminal) *Question {
+	q.setTerminal(t)
+	return q
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (q *Question) setTerminal(t Terminal) {
+	q.tui.Terminal(t)
+}
+
+// Session makes the question use the terminal and default styles of s,
+// replacing any terminal set before.
+func (q *Question) Session(s *Session) *Question {
+	q.setSession(s)
+	return q
+}
+
+// setSession moves the prompt to s.
+func (q *Question) setSession(s *Session) {
+	d := s.defaults()
+	q.session = s
+	q.tui.Terminal(s.Terminal())
+	q.icon.defaultFn = d.iconFn
+	q.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the question's answer in an AnswerSource.
+func (q *Question) Name(name string) *Question {
+	q.name = name
+	return q
 }
 
 // Title sets the question text.
@@ -70,25 +108,56 @@ func (q *Question) Validate(fn func(string) error) *Question {
 }
 
 // setAnswerFunc configures the answer transformation priority:
-// prompt-specific, global default, or identity function.
+// prompt-specific, session default, or identity function.
 func (q *Question) setAnswerFunc() {
 	if q.answerFn != nil {
 		q.tui.AnswerFunc(q.answerFn)
 		return
 	}
 
-	if defaultFuncs.answerFn != nil {
-		q.tui.AnswerFunc(defaultFuncs.answerFn)
+	if fn := q.session.defaults().answerFn; fn != nil {
+		q.tui.AnswerFunc(fn)
 		return
 	}
 
 	q.tui.AnswerFunc(func(input string) string { return input })
 }
 
+// promptName returns the name of the question.
+func (q *Question) promptName() string {
+	return q.name
+}
+
+// answer sets the value from a scripted answer after validating it.
+func (q *Question) answer(v any) error {
+	if q.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	if err := q.tui.Check(s); err != nil {
+		return err
+	}
+
+	*q.value = s
+	return nil
+}
+
 // Ask displays the question prompt and waits for input.
 func (q *Question) Ask() error {
+	return q.AskContext(context.Background())
+}
+
+// AskContext is like Ask but gives up when ctx is done, erasing the prompt
+// and returning an error wrapping ctx.Err().
+func (q *Question) AskContext(ctx context.Context) error {
 	question := fmt.Sprintf("%s%s ", q.icon.Get(), q.title.Get())
 	q.setAnswerFunc()
+	q.tui.Accessible(q.session.Accessible())
 
-	return q.tui.Display(question, q.value)
+	return q.tui.DisplayContext(ctx, question, q.value)
 }
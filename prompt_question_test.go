@@ -1,7 +1,11 @@
 package pardon
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
 )
 
 func TestQuestionCreation(t *testing.T) {
@@ -86,3 +90,92 @@ func TestQuestionWithValidate(t *testing.T) {
 		t.Error("Question with validation returned nil")
 	}
 }
+
+func TestQuestionResize(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(20, 5)
+	term.Type("hello world").Resize(12, 5)
+
+	NewQuestion().Icon("").Title("Name?").Value(&result).Terminal(term).Ask()
+
+	want := "Name? hello\nworld"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestQuestionWideCharacters(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(10, 5)
+
+	// 語 doesn't fit in the last cell of the first row and wraps whole, so
+	// the cursor before it is on the second row
+	term.Type("日本語").Press(keys.Left).Type("a")
+
+	NewQuestion().Icon("").Title("Name?").Value(&result).Terminal(term).Ask()
+
+	want := "Name? 日本\na語"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestQuestionLongTitleError(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(10, 6)
+	term.Type("bo").Press(keys.Enter).Type("b").Press(keys.Enter)
+
+	err := NewQuestion().
+		Icon("").
+		Title("What is your name?").
+		Validate(func(s string) error {
+			if len(s) < 3 {
+				return errors.New("too short")
+			}
+			return nil
+		}).
+		Value(&result).
+		Terminal(term).
+		Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	// The wrapped prompt is kept and the error line below it is cleared
+	want := "What is yo\nur name? b\nob"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestQuestionAccessible(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("bo").Press(keys.Enter).Type("bob").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	err := NewQuestion().
+		Icon("").
+		Title("Name?").
+		Validate(func(s string) error {
+			if len(s) < 3 {
+				return errors.New("too short")
+			}
+			return nil
+		}).
+		Value(&result).
+		Session(session).
+		Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "bob" {
+		t.Errorf("Ask() value = %q; want %q", result, "bob")
+	}
+	if got, want := term.Screen(), "Name? bo\nError: too short\nName? bob"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
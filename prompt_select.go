@@ -1,9 +1,9 @@
 package pardon
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/engmtcdrm/go-ansi"
 	"github.com/engmtcdrm/go-pardon/keys"
@@ -12,25 +12,72 @@ import (
 
 // Select represents a multiple-choice selection prompt.
 type Select[T comparable] struct {
+	name         string
+	session      *Session
+	term         tui.Terminal
 	icon         eval[string]
 	title        eval[string]
 	cursor       eval[string]
 	cursorPos    int
 	scrollOffset int
+	screen       *tui.Renderer
 	options      []Option[T]
+	filterable   bool
+	filter       []rune
+	filtered     []int   // Indexes into options shown in the current view
+	matches      [][]int // Matched rune positions for each entry of filtered
 	answerFn     func(string) string
+	matchFn      func(string) string
 	selectFn     func(string) string
 	value        *T
 }
 
 // NewSelect creates a new Select prompt instance.
 func NewSelect[T comparable]() *Select[T] {
-	return &Select[T]{
-		icon:    eval[string]{val: Icons.QuestionMark, defaultFn: defaultFuncs.iconFn},
-		title:   eval[string]{val: "", defaultFn: defaultFuncs.titleFn},
-		cursor:  eval[string]{val: "> ", defaultFn: defaultFuncs.cursorFn},
+	sel := &Select[T]{
+		icon:    eval[string]{val: Icons.QuestionMark},
+		title:   eval[string]{val: ""},
+		cursor:  eval[string]{val: "> "},
 		options: make([]Option[T], 0),
 	}
+	sel.setSession(defaultSession)
+	return sel
+}
+
+// Terminal sets the terminal the prompt reads from and renders to.
+func (sel *Select[T]) Terminal(t Terminal) *Select[T] {
+	sel.setTerminal(t)
+	return sel
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (sel *Select[T]) setTerminal(t Terminal) {
+	if t != nil {
+		sel.term = t
+	}
+}
+
+// Session makes the prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (sel *Select[T]) Session(s *Session) *Select[T] {
+	sel.setSession(s)
+	return sel
+}
+
+// setSession moves the prompt to s.
+func (sel *Select[T]) setSession(s *Session) {
+	d := s.defaults()
+	sel.session = s
+	sel.term = s.Terminal()
+	sel.icon.defaultFn = d.iconFn
+	sel.title.defaultFn = d.titleFn
+	sel.cursor.defaultFn = d.cursorFn
+}
+
+// Name sets the name used to look up the selection in an AnswerSource.
+func (sel *Select[T]) Name(name string) *Select[T] {
+	sel.name = name
+	return sel
 }
 
 // Title sets the prompt title text that will be displayed to the user.
@@ -100,15 +147,27 @@ func (sel *Select[T]) SelectFunc(fn func(string) string) *Select[T] {
 	return sel
 }
 
-// setAnswerFunc configures the answer transformation priority:
-// prompt-specific, global default, or the string itself.
+// Filter enables type-to-filter mode, where typed characters narrow the
+// options using fuzzy matching.
+func (sel *Select[T]) Filter(enabled bool) *Select[T] {
+	sel.filterable = enabled
+	return sel
+}
+
+// MatchFunc sets a function to highlight the characters matched by the filter.
+func (sel *Select[T]) MatchFunc(fn func(string) string) *Select[T] {
+	sel.matchFn = fn
+	return sel
+}
+
+// getSelectFunc returns the formatted text for the highlighted option.
 func (sel *Select[T]) getSelectFunc(s string) string {
 	if sel.selectFn != nil {
 		return sel.selectFn(s)
 	}
 
-	if defaultFuncs.selectFn != nil {
-		return defaultFuncs.selectFn(s)
+	if fn := sel.session.defaults().selectFn; fn != nil {
+		return fn(s)
 	}
 
 	return s
@@ -120,15 +179,87 @@ func (sel *Select[T]) getAnswerFunc(answer string) string {
 		return sel.answerFn(answer)
 	}
 
-	if defaultFuncs.answerFn != nil {
-		return defaultFuncs.answerFn(answer)
+	if fn := sel.session.defaults().answerFn; fn != nil {
+		return fn(answer)
 	}
 
 	return answer
 }
 
+// getMatchFunc returns s highlighted as a filter match, underlining it when
+// no match function has been configured.
+func (sel *Select[T]) getMatchFunc(s string) string {
+	if sel.matchFn != nil {
+		return sel.matchFn(s)
+	}
+
+	if fn := sel.session.defaults().matchFn; fn != nil {
+		return fn(s)
+	}
+
+	return ansi.Underline + s + ansi.ResetUnderline
+}
+
+// applyFilter recomputes the filtered view of the options, keeping the
+// cursor on the same option when it is still visible.
+func (sel *Select[T]) applyFilter() {
+	current := -1
+	if sel.cursorPos < len(sel.filtered) {
+		current = sel.filtered[sel.cursorPos]
+	}
+
+	if len(sel.filter) == 0 {
+		sel.filtered = make([]int, len(sel.options))
+		for i := range sel.options {
+			sel.filtered[i] = i
+		}
+		sel.matches = make([][]int, len(sel.options))
+	} else {
+		keys := make([]string, len(sel.options))
+		for i, opt := range sel.options {
+			keys[i] = opt.Key
+		}
+		sel.filtered, sel.matches = fuzzyFilter(string(sel.filter), keys)
+	}
+
+	sel.cursorPos = 0
+	sel.scrollOffset = 0
+	for i, idx := range sel.filtered {
+		if idx == current {
+			sel.cursorPos = i
+			break
+		}
+	}
+}
+
+// promptName returns the name of the select prompt.
+func (sel *Select[T]) promptName() string {
+	return sel.name
+}
+
+// answer selects the option matching a scripted answer.
+func (sel *Select[T]) answer(v any) error {
+	if sel.value == nil {
+		return ErrNoValue
+	}
+
+	idx, err := answerOption(sel.options, v)
+	if err != nil {
+		return err
+	}
+
+	*sel.value = sel.options[idx].Value
+	return nil
+}
+
 // Ask displays the select prompt and waits for user selection.
 func (sel *Select[T]) Ask() error {
+	return sel.AskContext(context.Background())
+}
+
+// AskContext displays the select prompt until an option is chosen or ctx is
+// done, in which case the title and options are erased.
+func (sel *Select[T]) AskContext(ctx context.Context) error {
 	if sel.title.val == "" && sel.title.fn == nil {
 		return ErrNoTitle
 	}
@@ -141,94 +272,168 @@ func (sel *Select[T]) Ask() error {
 		return ErrNoSelectOptions
 	}
 
+	if !tui.IsInteractive(sel.term) || sel.session.Accessible() {
+		return sel.askLines(ctx)
+	}
+
+	sel.filter = sel.filter[:0]
+	sel.applyFilter()
+
+	defer tui.Guard(sel.term)()
 	defer func() {
-		fmt.Print(ansi.ShowCursor)
+		fmt.Fprint(sel.term, ansi.ShowCursor)
 	}()
+	reader := tui.NewKeyReader(sel.term)
+	defer reader.Close()
 
-	// Print the question
-	fmt.Printf("%s%s\n", sel.icon.Get(), sel.title.Get())
-
-	sel.renderOptions(false)
-	fmt.Print(ansi.HideCursor)
+	sel.screen = tui.NewRenderer(sel.term)
+	fmt.Fprint(sel.term, ansi.HideCursor)
+	sel.renderOptions()
 
 	for {
-		keyCode := tui.GetInput()
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				sel.screen.Erase()
+			}
+			return err
+		}
+
+		if pos, ok := tui.MoveListCursor(ev, sel.cursorPos, len(sel.filtered), sel.pageSize()); ok {
+			sel.cursorPos = pos
+			sel.renderOptions()
+			continue
+		}
 
-		switch keyCode {
-		case keys.KeyCtrlC:
+		switch {
+		case ev.Key == keys.Resize:
+			sel.renderOptions()
+			continue
+		case ev.Key == keys.Resume:
+			sel.resume()
+			continue
+		case ev == keys.Ctrl('c'):
 			return ErrUserAborted
-		case keys.KeyEnter, keys.KeyCarriageReturn:
-			*sel.value = sel.options[sel.cursorPos].Value
-			visibleOptions := tui.Min(len(sel.options), tui.GetTerminalHeight()-3)
-			tui.RenderClearAndReposition(visibleOptions+1, sel.icon.Get(), sel.title.Get(), sel.getAnswerFunc(sel.options[sel.cursorPos].Key))
+		case ev.Key == keys.Enter:
+			if len(sel.filtered) == 0 {
+				continue
+			}
+
+			selected := sel.options[sel.filtered[sel.cursorPos]]
+			*sel.value = selected.Value
+			sel.screen.Finish(sel.icon.Get() + sel.title.Get() + " " + sel.getAnswerFunc(selected.Key))
 			return nil
-		case keys.KeyUp:
-			sel.cursorPos = (sel.cursorPos + len(sel.options) - 1) % len(sel.options)
-			sel.renderOptions(true)
-		case keys.KeyDown:
-			sel.cursorPos = (sel.cursorPos + 1) % len(sel.options)
-			sel.renderOptions(true)
+		case !sel.filterable:
+			continue
+		case ev.Key == keys.Backspace:
+			if len(sel.filter) == 0 {
+				continue
+			}
+			sel.filter = sel.filter[:len(sel.filter)-1]
+			sel.applyFilter()
+		case ev.Key == keys.Escape:
+			if len(sel.filter) == 0 {
+				continue
+			}
+			sel.filter = sel.filter[:0]
+			sel.applyFilter()
+		case ev.IsPrintable():
+			sel.filter = append(sel.filter, ev.Rune)
+			sel.applyFilter()
+		default:
+			continue
 		}
+
+		sel.renderOptions()
 	}
 }
 
-// renderOptions displays the list of available options to the user.
-func (sel *Select[T]) renderOptions(redraw bool) {
-	termHeight := tui.GetTerminalHeight()
-	termHeight = termHeight - 3 // Space for prompt and cursor movement
-	selectSize := len(sel.options)
+// askLines lists the numbered options and reads the choice a line at a time
+// for non-interactive terminals and accessible mode.
+func (sel *Select[T]) askLines(ctx context.Context) error {
+	labels := make([]string, len(sel.options))
+	for i, opt := range sel.options {
+		labels[i] = opt.Key
+	}
+
+	fmt.Fprintf(sel.term, "%s%s\n", sel.icon.Get(), sel.title.Get())
+	renderNumberedOptions(sel.term, labels, func(int) string { return "" })
+
+	lines := newLineAsker(sel.term, sel.session)
+	prompt := fmt.Sprintf("Enter a number (1-%d) or name: ", len(labels))
+	for {
+		text, err := lines.read(ctx, prompt)
+		if err != nil {
+			return err
+		}
 
-	// Ensure scroll offset follows cursor movement
-	if sel.cursorPos < sel.scrollOffset {
-		sel.scrollOffset = sel.cursorPos
-	} else if sel.cursorPos >= sel.scrollOffset+termHeight {
-		sel.scrollOffset = sel.cursorPos - termHeight + 1
+		idx, err := chooseOption(text, labels)
+		if err != nil {
+			lines.reject(text, err)
+			continue
+		}
+
+		*sel.value = sel.options[idx].Value
+		lines.accept(text, sel.getAnswerFunc(labels[idx]), labels[idx])
+		return nil
 	}
+}
 
+// resume draws the prompt again in full after the process was continued,
+// as the shell may have written over it.
+func (sel *Select[T]) resume() {
+	sel.screen.Reset()
+	fmt.Fprint(sel.term, ansi.HideCursor)
+	sel.renderOptions()
+}
+
+// pageSize returns the number of options visible at once.
+func (sel *Select[T]) pageSize() int {
+	termHeight := tui.TerminalHeight(sel.term) - 3 // Space for prompt and cursor movement
+	if sel.filterable {
+		termHeight-- // Space for the filter line
+	}
+	return termHeight
+}
+
+// renderOptions displays the list of available options to the user.
+func (sel *Select[T]) renderOptions() {
+	termHeight := sel.pageSize()
 	selectCursor := sel.cursor.Get()
-	visibleLines := tui.Min(selectSize, termHeight)
-
-	if redraw {
-		// For terminal optimization: build entire output first, then write atomically
-		var output strings.Builder
-
-		// Move cursor up to start position
-		output.WriteString(ansi.CursorUp(visibleLines))
-
-		// Build all lines in memory first
-		for i := sel.scrollOffset; i < tui.Min(sel.scrollOffset+termHeight, selectSize); i++ {
-			selectedOption := sel.options[i]
-			cursor := strings.Repeat(" ", utf8.RuneCountInString(ansi.StripCodes(selectCursor)))
-
-			// Clear line and build content
-			output.WriteString("\r")
-			output.WriteString(ansi.ClearLine)
-
-			if i == sel.cursorPos {
-				cursor = sel.getSelectFunc(selectCursor)
-				output.WriteString(cursor)
-				output.WriteString(sel.getSelectFunc(selectedOption.Key))
-			} else {
-				output.WriteString(cursor)
-				output.WriteString(selectedOption.Key)
-			}
-			output.WriteString("\n")
-		}
+	padding := strings.Repeat(" ", tui.StringWidth(selectCursor))
 
-		// Write everything at once to minimize flicker
-		fmt.Print(output.String())
-	} else {
-		// Initial render without redraw
-		for i := sel.scrollOffset; i < tui.Min(sel.scrollOffset+termHeight, selectSize); i++ {
-			selectedOption := sel.options[i]
-			cursor := strings.Repeat(" ", utf8.RuneCountInString(ansi.StripCodes(selectCursor)))
-
-			if i == sel.cursorPos {
-				cursor = sel.getSelectFunc(selectCursor)
-				fmt.Printf("%s%s\n", cursor, sel.getSelectFunc(selectedOption.Key))
-			} else {
-				fmt.Printf("%s%s\n", cursor, selectedOption.Key)
-			}
+	lines := make([]string, 0, tui.Min(len(sel.filtered), termHeight)+3)
+	lines = append(lines, sel.icon.Get()+sel.title.Get())
+
+	if sel.filterable {
+		lines = append(lines, "Filter: "+string(sel.filter))
+	}
+
+	if len(sel.filtered) == 0 {
+		lines = append(lines, padding+"no matches")
+	}
+
+	// Ensure scroll offset follows cursor movement, showing fewer options
+	// when long ones wrap so the prompt still fits on screen
+	width := tui.TerminalWidth(sel.term)
+	termHeight -= tui.ScreenRows(lines, width) - len(lines)
+
+	var end int
+	sel.scrollOffset, end = tui.ScrollWindow(sel.cursorPos, sel.scrollOffset, len(sel.filtered), termHeight)
+	sel.scrollOffset, end = tui.FitWindow(sel.cursorPos, sel.scrollOffset, end, termHeight, func(i int) int {
+		return tui.ScreenRows([]string{padding + sel.options[sel.filtered[i]].Key}, width)
+	})
+
+	for i := sel.scrollOffset; i < end; i++ {
+		key := highlightMatches(sel.options[sel.filtered[i]].Key, sel.matches[i], sel.getMatchFunc)
+
+		if i == sel.cursorPos {
+			lines = append(lines, sel.getSelectFunc(selectCursor)+sel.getSelectFunc(key))
+		} else {
+			lines = append(lines, padding+key)
 		}
 	}
+
+	// Only the lines that changed are written, keeping redraws small
+	sel.screen.Draw(lines)
 }
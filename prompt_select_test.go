@@ -1,7 +1,11 @@
 package pardon
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
 )
 
 func TestSelectCreation(t *testing.T) {
@@ -208,3 +212,302 @@ func TestNewOption(t *testing.T) {
 		t.Errorf("NewOption Value = %q; want %q", option.Value, "test value")
 	}
 }
+
+func TestSelectFilter(t *testing.T) {
+	options := []Option[string]{
+		{Key: "us-east-1", Value: "use1"},
+		{Key: "us-west-2", Value: "usw2"},
+		{Key: "eu-west-1", Value: "euw1"},
+	}
+
+	var result string
+	selectPrompt := NewSelect[string]().Options(options...).Value(&result).Filter(true)
+
+	if !selectPrompt.filterable {
+		t.Fatal("Filter(true) did not enable filtering")
+	}
+
+	selectPrompt.applyFilter()
+	if len(selectPrompt.filtered) != len(options) {
+		t.Fatalf("Unfiltered view has %d options; want %d", len(selectPrompt.filtered), len(options))
+	}
+
+	// Move the cursor to "eu-west-1" and narrow the list
+	selectPrompt.cursorPos = 2
+	selectPrompt.filter = []rune("west")
+	selectPrompt.applyFilter()
+
+	if len(selectPrompt.filtered) != 2 {
+		t.Fatalf("Filtered view has %d options; want 2", len(selectPrompt.filtered))
+	}
+
+	if got := selectPrompt.options[selectPrompt.filtered[selectPrompt.cursorPos]].Value; got != "euw1" {
+		t.Errorf("Cursor moved to %q after filtering; want %q", got, "euw1")
+	}
+
+	selectPrompt.filter = []rune("xyz")
+	selectPrompt.applyFilter()
+
+	if len(selectPrompt.filtered) != 0 {
+		t.Errorf("Filtered view has %d options; want 0", len(selectPrompt.filtered))
+	}
+
+	if selectPrompt.cursorPos != 0 || selectPrompt.scrollOffset != 0 {
+		t.Errorf("Cursor/scroll = %d/%d for empty view; want 0/0", selectPrompt.cursorPos, selectPrompt.scrollOffset)
+	}
+}
+
+func TestSelectMatchFunc(t *testing.T) {
+	var result string
+	selectPrompt := NewSelect[string]().Value(&result)
+
+	if got := selectPrompt.getMatchFunc("ab"); got == "ab" {
+		t.Error("Default match function should highlight the text")
+	}
+
+	selectPrompt.MatchFunc(func(s string) string { return "<" + s + ">" })
+	if got := selectPrompt.getMatchFunc("ab"); got != "<ab>" {
+		t.Errorf("getMatchFunc() = %q; want %q", got, "<ab>")
+	}
+}
+
+func newColorSelect(term *pardontest.Terminal, result *int) *Select[int] {
+	return NewSelect[int]().
+		Icon("").
+		Title("Choose a color:").
+		Options(NewOption("Red", 1), NewOption("Blue", 2), NewOption("Green", 3)).
+		Value(result).
+		Terminal(term)
+}
+
+func TestSelectScreen(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down)
+
+	// The script ends before Enter, leaving the list on screen
+	if err := newColorSelect(term, &result).Ask(); err == nil {
+		t.Fatal("Ask() error = nil; want end of input")
+	}
+
+	want := "Choose a color:\n  Red\n> Blue\n  Green"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+
+	if term.IsRaw() || !term.CursorVisible() {
+		t.Error("Terminal was not restored after Ask()")
+	}
+}
+
+func TestSelectScreenAnswer(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down, keys.Down, keys.Enter)
+
+	if err := newColorSelect(term, &result).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != 3 {
+		t.Errorf("Ask() value = %d; want 3", result)
+	}
+	if got, want := term.Screen(), "Choose a color: Green"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectScreenFilter(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("gr")
+
+	newColorSelect(term, &result).MatchFunc(func(s string) string { return s }).Filter(true).Ask()
+
+	want := "Choose a color:\nFilter: gr\n> Green"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectResizeNarrower(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down).Resize(10, 10)
+
+	newColorSelect(term, &result).Ask()
+
+	// The title now wraps onto a second row
+	want := "Choose a c\nolor:\n  Red\n> Blue\n  Green"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectResizeAnswer(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down).Resize(10, 10).Press(keys.Enter)
+
+	if err := newColorSelect(term, &result).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if got, want := term.Screen(), "Choose a c\nolor: Blue"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectResizeShorter(t *testing.T) {
+	var result int
+	options := make([]Option[int], 8)
+	for i := range options {
+		options[i] = NewOption(string(rune('A'+i)), i)
+	}
+
+	term := pardontest.NewTerminal(40, 12)
+	term.Press(keys.Down, keys.Down).Resize(40, 5)
+
+	NewSelect[int]().Icon("").Title("Pick:").Options(options...).Value(&result).Terminal(term).Ask()
+
+	// Two options fit below the title, keeping the cursor in view
+	want := "Pick:\n  B\n> C"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectSuspend(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down).Send(keys.Ctrl('z')).Press(keys.Enter)
+
+	if err := newColorSelect(term, &result).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if term.Suspended() != 1 {
+		t.Errorf("Suspended() = %d; want 1", term.Suspended())
+	}
+	if result != 2 {
+		t.Errorf("Ask() value = %d; want 2", result)
+	}
+
+	// The prompt is drawn again below the one left behind by the suspend
+	want := "Choose a color:\n  Red\n> Blue\n  Green\nChoose a color: Blue"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectPanicRestoresTerminal(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recover() = %v; want boom", r)
+		}
+		if term.IsRaw() || !term.CursorVisible() {
+			t.Error("Terminal was not restored after a panic")
+		}
+	}()
+
+	newColorSelect(term, &result).SelectFunc(func(s string) string {
+		if strings.Contains(s, "Blue") {
+			panic("boom")
+		}
+		return s
+	}).Ask()
+}
+
+func TestSelectWideCursor(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down)
+
+	NewSelect[int]().
+		Icon("").
+		Title("Pick:").
+		Cursor("👉 ").
+		Options(NewOption("日本", 1), NewOption("中文", 2)).
+		Value(&result).
+		Terminal(term).
+		Ask()
+
+	// The emoji cursor is two cells wide, so other options are padded by three
+	want := "Pick:\n   日本\n👉 中文"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectLongOptions(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(12, 6)
+	term.Press(keys.Down)
+
+	NewSelect[int]().
+		Icon("").
+		Title("Pick:").
+		Options(NewOption("Short", 1), NewOption("A much longer option", 2), NewOption("Tiny", 3)).
+		Value(&result).
+		Terminal(term).
+		Ask()
+
+	// The wrapped option takes two rows, leaving no room for the last one
+	want := "Pick:\n  Short\n> A much lon\nger option"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectLongAnswer(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(12, 6)
+	term.Press(keys.Down, keys.Down, keys.Enter)
+
+	err := NewSelect[int]().
+		Icon("").
+		Title("Pick:").
+		Options(NewOption("Short", 1), NewOption("A much longer option", 2), NewOption("Tiny", 3)).
+		Value(&result).
+		Terminal(term).
+		Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if got, want := term.Screen(), "Pick: Tiny"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSelectAccessible(t *testing.T) {
+	var result int
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("5").Press(keys.Enter).Type("blue").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetAccessible(true)
+
+	if err := newColorSelect(term, &result).Session(session).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != 2 {
+		t.Errorf("Ask() value = %d; want 2", result)
+	}
+
+	// Typed answers are echoed by the terminal and nothing is redrawn
+	want := "Choose a color:\n" +
+		"  1) Red\n  2) Blue\n  3) Green\n" +
+		"Enter a number (1-3) or name: 5\n" +
+		"Error: 5 is not between 1 and 3\n" +
+		"Enter a number (1-3) or name: blue\n" +
+		"Selected: Blue"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package pardon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Text represents a prompt for several lines of text typed in place. Enter
+// starts a new line, and Ctrl-D or Alt-Enter submits the text.
+type Text struct {
+	name     string
+	session  *Session
+	icon     eval[string]
+	title    eval[string]
+	value    *string
+	answerFn func(string) string
+	tui      *tui.InputPrompt[string]
+}
+
+// NewText creates a new Text prompt instance showing 5 lines at a time.
+func NewText() *Text {
+	t := &Text{
+		icon:  eval[string]{val: Icons.QuestionMark},
+		title: eval[string]{val: ""},
+		tui:   tui.NewTextPrompt(),
+	}
+	t.setSession(defaultSession)
+	return t
+}
+
+// Terminal sets the terminal the text prompt reads from and renders to.
+func (t *Text) Terminal(term Terminal) *Text {
+	t.setTerminal(term)
+	return t
+}
+
+// setTerminal replaces the prompt's terminal, ignoring nil.
+func (t *Text) setTerminal(term Terminal) {
+	t.tui.Terminal(term)
+}
+
+// Session makes the text prompt use the terminal and default styles of s,
+// replacing any terminal set before.
+func (t *Text) Session(s *Session) *Text {
+	t.setSession(s)
+	return t
+}
+
+// setSession moves the prompt to s.
+func (t *Text) setSession(s *Session) {
+	d := s.defaults()
+	t.session = s
+	t.tui.Terminal(s.Terminal())
+	t.icon.defaultFn = d.iconFn
+	t.title.defaultFn = d.titleFn
+}
+
+// Name sets the name used to look up the text in an AnswerSource.
+func (t *Text) Name(name string) *Text {
+	t.name = name
+	return t
+}
+
+// Title sets the prompt text.
+func (t *Text) Title(title string) *Text {
+	t.title.val = title
+	t.title.fn = nil
+	return t
+}
+
+// TitleFunc sets a dynamic title function.
+func (t *Text) TitleFunc(fn func(string) string) *Text {
+	t.title.fn = fn
+	return t
+}
+
+// Icon sets the prompt icon.
+func (t *Text) Icon(s string) *Text {
+	t.icon.val = s
+	t.icon.fn = nil
+	return t
+}
+
+// IconFunc sets a dynamic icon function.
+func (t *Text) IconFunc(fn func(string) string) *Text {
+	t.icon.fn = fn
+	return t
+}
+
+// Value sets the text to fill in, which also holds the default.
+func (t *Text) Value(value *string) *Text {
+	t.value = value
+	return t
+}
+
+// Height sets how many lines are shown at once. Longer text scrolls to keep
+// the cursor in view.
+func (t *Text) Height(n int) *Text {
+	t.tui.Height(n)
+	return t
+}
+
+// MaxLines sets the most lines the text can have. Zero means no limit.
+func (t *Text) MaxLines(n int) *Text {
+	t.tui.MaxLines(n)
+	return t
+}
+
+// CharLimit sets the most characters the text can have, counting each line
+// break as one. Zero means no limit.
+func (t *Text) CharLimit(n int) *Text {
+	t.tui.CharLimit(n)
+	return t
+}
+
+// AnswerFunc sets a function to transform the final answer, which shows
+// the first line of the text.
+func (t *Text) AnswerFunc(fn func(string) string) *Text {
+	t.answerFn = fn
+	return t
+}
+
+// Validate sets input validation.
+func (t *Text) Validate(fn func(string) error) *Text {
+	t.tui.Validate(fn)
+	return t
+}
+
+// setAnswerFunc configures the answer transformation priority:
+// prompt-specific, session default, or identity function, applied to a
+// summary of the text.
+func (t *Text) setAnswerFunc() {
+	fn := t.answerFn
+	if fn == nil {
+		fn = t.session.defaults().answerFn
+	}
+	if fn == nil {
+		fn = func(input string) string { return input }
+	}
+
+	t.tui.AnswerFunc(func(input string) string { return fn(summarize(input)) })
+}
+
+// promptName returns the name of the text prompt.
+func (t *Text) promptName() string {
+	return t.name
+}
+
+// answer sets the value from a scripted answer after validating it.
+func (t *Text) answer(v any) error {
+	if t.value == nil {
+		return ErrNoValue
+	}
+
+	s, err := answerString(v)
+	if err != nil {
+		return err
+	}
+
+	if err := t.tui.Check(s); err != nil {
+		return err
+	}
+
+	*t.value = s
+	return nil
+}
+
+// Ask displays the text prompt and waits for input.
+func (t *Text) Ask() error {
+	return t.AskContext(context.Background())
+}
+
+// AskContext is like Ask but gives up when ctx is done, erasing the prompt
+// and returning an error wrapping ctx.Err().
+func (t *Text) AskContext(ctx context.Context) error {
+	if t.title.val == "" && t.title.fn == nil {
+		return ErrNoTitle
+	}
+
+	if t.value == nil {
+		return ErrNoValue
+	}
+
+	prompt := fmt.Sprintf("%s%s ", t.icon.Get(), t.title.Get())
+	t.setAnswerFunc()
+	t.tui.Accessible(t.session.Accessible())
+
+	return t.tui.DisplayContext(ctx, prompt, t.value)
+}
@@ -0,0 +1,157 @@
+package pardon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+func TestTextCreation(t *testing.T) {
+	var result string
+	if err := NewText().Value(&result).Ask(); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoTitle)
+	}
+	if err := NewText().Title("Notes:").Ask(); !errors.Is(err, ErrNoValue) {
+		t.Errorf("Ask() error = %v; want %v", err, ErrNoValue)
+	}
+}
+
+func TestTextScreen(t *testing.T) {
+	var result string
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("Hello").Press(keys.Enter).Type("World").Send(keys.Ctrl('d'))
+
+	if err := NewText().Icon("").Title("Notes:").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "Hello\nWorld" {
+		t.Errorf("Ask() value = %q; want %q", result, "Hello\nWorld")
+	}
+	if got, want := term.Screen(), "Notes: Hello (+1 more line)"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestTextEditing(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		input func(*pardontest.Terminal)
+		limit func(*Text) *Text
+		want  string
+	}{
+		{"alt enter submits", "", func(term *pardontest.Terminal) {
+			term.Type("a").Send(keys.Named(keys.Enter, keys.ModAlt))
+		}, nil, "a"},
+		{"backspace joins lines", "", func(term *pardontest.Terminal) {
+			term.Type("ab").Press(keys.Enter).Type("cd").Press(keys.Home, keys.Backspace).Send(keys.Ctrl('d'))
+		}, nil, "abcd"},
+		{"delete joins lines", "ab\ncd", func(term *pardontest.Terminal) {
+			term.Press(keys.Up, keys.End, keys.Delete).Send(keys.Ctrl('d'))
+		}, nil, "abcd"},
+		{"enter splits line", "abcd", func(term *pardontest.Terminal) {
+			term.Press(keys.Left, keys.Left, keys.Enter).Send(keys.Ctrl('d'))
+		}, nil, "ab\ncd"},
+		{"up keeps column", "abcdef\nxy\n123", func(term *pardontest.Terminal) {
+			term.Press(keys.Left, keys.Up, keys.Up).Type("Z").Send(keys.Ctrl('d'))
+		}, nil, "abZcdef\nxy\n123"},
+		{"left wraps to previous line", "ab\ncd", func(term *pardontest.Terminal) {
+			term.Press(keys.Home, keys.Left).Type("!").Send(keys.Ctrl('d'))
+		}, nil, "ab!\ncd"},
+		{"max lines", "", func(term *pardontest.Terminal) {
+			term.Type("a").Press(keys.Enter).Type("b").Press(keys.Enter).Type("c").Send(keys.Ctrl('d'))
+		}, func(t *Text) *Text { return t.MaxLines(2) }, "a\nbc"},
+		{"char limit", "", func(term *pardontest.Terminal) {
+			term.Type("ab").Press(keys.Enter).Type("cd").Send(keys.Ctrl('d'))
+		}, func(t *Text) *Text { return t.CharLimit(4) }, "ab\nc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value
+			term := pardontest.NewTerminal(40, 10)
+			tt.input(term)
+
+			text := NewText().Title("Notes:").Value(&result).Terminal(term)
+			if tt.limit != nil {
+				text = tt.limit(text)
+			}
+			if err := text.Ask(); err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Ask() value = %q; want %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextScrolling(t *testing.T) {
+	result := "one\ntwo\nthree\nfour"
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Up, keys.Up)
+
+	// The prompt is left on screen once the scripted input runs out
+	NewText().Icon("").Title("Notes:").Height(2).Value(&result).Terminal(term).Ask()
+
+	want := "Notes: [Ctrl-D to submit]\n  two\n  three"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+	if row, col := term.Cursor(); row != 1 || col != 5 {
+		t.Errorf("Cursor() = %d, %d; want 1, 5", row, col)
+	}
+}
+
+func TestTextValidate(t *testing.T) {
+	result := ""
+	term := pardontest.NewTerminal(40, 10)
+	term.Send(keys.Ctrl('d'))
+
+	required := func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return errors.New("enter some notes")
+		}
+		return nil
+	}
+	NewText().Icon("").Title("Notes:").Validate(required).Value(&result).Terminal(term).Ask()
+
+	want := "Notes: [Ctrl-D to submit]\n\n* enter some notes"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestTextLines(t *testing.T) {
+	result := "old"
+	var out strings.Builder
+	term := NewLineTerminal(strings.NewReader("first\nsecond\n.\n"), &out)
+
+	if err := NewText().Icon("").Title("Notes:").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "first\nsecond" {
+		t.Errorf("Ask() value = %q; want %q", result, "first\nsecond")
+	}
+	want := "Notes:\nEnter text, ending with a line holding only \".\":\nfirst (+1 more line)\n"
+	if out.String() != want {
+		t.Errorf("output = %q; want %q", out.String(), want)
+	}
+}
+
+func TestTextAnswer(t *testing.T) {
+	var result string
+	text := NewText().MaxLines(2).Value(&result)
+
+	if err := text.answer("a\nb"); err != nil || result != "a\nb" {
+		t.Errorf("answer(\"a\\nb\") = %v, value %q", err, result)
+	}
+	if err := text.answer("a\nb\nc"); err == nil || err.Error() != "enter no more than 2 lines" {
+		t.Errorf("answer(\"a\\nb\\nc\") error = %v; want the line limit", err)
+	}
+}
@@ -0,0 +1,129 @@
+package pardon
+
+import (
+	"os"
+	"sync"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Session holds what a group of prompts share: the terminal they read from
+// and render to, and the default functions used to style them. A Session is
+// safe for concurrent use, and prompts in different sessions can be asked
+// at the same time, for example from parallel tests.
+//
+// The package-level SetDefault functions and prompt constructors use a
+// default session on standard input and output.
+type Session struct {
+	mu         sync.RWMutex
+	term       Terminal
+	funcs      funcs
+	accessible bool
+}
+
+// AccessibleEnv is the environment variable that turns on accessible mode
+// for every session when set to anything but 0.
+const AccessibleEnv = "PARDON_ACCESSIBLE"
+
+// defaultSession is the session used by the package-level API.
+var defaultSession = NewSession(nil)
+
+// NewSession creates a Session on t, or on standard input and output if t
+// is nil, with no default styles.
+func NewSession(t Terminal) *Session {
+	if t == nil {
+		t = tui.DefaultTerminal()
+	}
+	return &Session{term: t}
+}
+
+// DefaultSession returns the session used by the package-level API.
+func DefaultSession() *Session {
+	return defaultSession
+}
+
+// Terminal returns the terminal prompts in the session use.
+func (s *Session) Terminal() Terminal {
+	return s.term
+}
+
+// SetAccessible turns accessible mode on or off for prompts in the session.
+// In accessible mode prompts are written as plain sequential text suited to
+// screen readers: nothing is redrawn in place, options are listed by number,
+// answers are typed rather than chosen with the arrow keys, and the choice
+// is announced once made. Accessible mode is also on while the
+// PARDON_ACCESSIBLE environment variable is set.
+func (s *Session) SetAccessible(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessible = on
+}
+
+// Accessible reports whether prompts in the session use accessible mode.
+func (s *Session) Accessible() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if env := os.Getenv(AccessibleEnv); env != "" && env != "0" {
+		return true
+	}
+	return s.accessible
+}
+
+// SetDefaultAnswerFunc sets the default answer transformation function for
+// prompts in the session.
+func (s *Session) SetDefaultAnswerFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.answerFn, fn)
+}
+
+// SetDefaultCursorFunc sets the default cursor formatting function for
+// prompts in the session.
+func (s *Session) SetDefaultCursorFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.cursorFn, fn)
+}
+
+// SetDefaultIconFunc sets the default icon transformation function for
+// prompts in the session.
+func (s *Session) SetDefaultIconFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.iconFn, fn)
+}
+
+// SetDefaultMatchFunc sets the default function used to highlight
+// characters matched by a filter for prompts in the session.
+func (s *Session) SetDefaultMatchFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.matchFn, fn)
+}
+
+// SetDefaultSelectFunc sets the default selection formatting function for
+// prompts in the session.
+func (s *Session) SetDefaultSelectFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.selectFn, fn)
+}
+
+// SetDefaultTitleFunc sets the default title transformation function for
+// prompts in the session.
+func (s *Session) SetDefaultTitleFunc(fn func(string) string) {
+	s.setFunc(&s.funcs.titleFn, fn)
+}
+
+// setFunc replaces one of the session's default functions.
+func (s *Session) setFunc(field *func(string) string, fn func(string) string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	*field = fn
+}
+
+// defaults returns a copy of the session's default functions.
+func (s *Session) defaults() funcs {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.funcs
+}
+
+// sessionSetter is implemented by prompts that can be moved to a Session by a Form.
+type sessionSetter interface {
+	setSession(s *Session)
+}
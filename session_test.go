@@ -0,0 +1,142 @@
+package pardon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+func TestNewSession(t *testing.T) {
+	term := pardontest.NewTerminal(40, 10)
+	if got := NewSession(term).Terminal(); got != term {
+		t.Errorf("Terminal() = %v; want the session's terminal", got)
+	}
+
+	if NewSession(nil).Terminal() == nil {
+		t.Error("NewSession(nil) has no terminal; want standard input and output")
+	}
+
+	if DefaultSession() != defaultSession {
+		t.Error("DefaultSession() is not the session of the package-level API")
+	}
+}
+
+func TestSessionDefaults(t *testing.T) {
+	term := pardontest.NewTerminal(40, 10)
+	term.Type("bob").Press(keys.Enter)
+
+	session := NewSession(term)
+	session.SetDefaultIconFunc(func(s string) string { return "<" + s + ">" })
+	session.SetDefaultAnswerFunc(strings.ToUpper)
+
+	var name string
+	if err := NewQuestion().Session(session).Title("Name?").Value(&name).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if got, want := term.Screen(), "<[?] >Name? BOB"; got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+
+	// The package-level defaults are left alone
+	if defaultSession.defaults().answerFn != nil {
+		t.Error("Session defaults leaked into the default session")
+	}
+}
+
+func TestFormSession(t *testing.T) {
+	term := pardontest.NewTerminal(40, 10)
+	term.Press(keys.Down, keys.Enter).Type("y")
+
+	session := NewSession(term)
+	session.SetDefaultAnswerFunc(func(s string) string { return "[" + s + "]" })
+
+	var color int
+	var sure bool
+	err := NewForm(
+		newColorSelect(nil, &color),
+		NewConfirm().Icon("").Title("Sure?").Value(&sure),
+	).Session(session).Ask()
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	want := "Choose a color: [Blue]\nSure? [Y]"
+	if got := term.Screen(); got != want {
+		t.Errorf("Screen() = %q; want %q", got, want)
+	}
+}
+
+func TestSessionsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		term := pardontest.NewTerminal(40, 10)
+		for range i % 3 {
+			term.Press(keys.Down)
+		}
+		term.Press(keys.Enter)
+
+		session := NewSession(term)
+		session.SetDefaultAnswerFunc(func(s string) string { return fmt.Sprintf("%s#%d", s, i) })
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			var result int
+			if err := newColorSelect(term, &result).Session(session).Ask(); err != nil {
+				t.Errorf("Ask() error = %v", err)
+				return
+			}
+
+			if result != i%3+1 {
+				t.Errorf("Ask() value = %d; want %d", result, i%3+1)
+			}
+			if want := fmt.Sprintf("#%d", i); !term.Contains(want) {
+				t.Errorf("Screen() = %q; want answer styled with %q", term.Screen(), want)
+			}
+		}()
+
+		// Styles can be changed while the prompt is being asked
+		go func() {
+			defer wg.Done()
+			session.SetDefaultSelectFunc(strings.ToUpper)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSessionAccessible(t *testing.T) {
+	t.Setenv(AccessibleEnv, "")
+	session := NewSession(pardontest.NewTerminal(40, 10))
+	if session.Accessible() {
+		t.Error("Accessible() = true; want false by default")
+	}
+
+	session.SetAccessible(true)
+	if !session.Accessible() {
+		t.Error("Accessible() = false after SetAccessible(true)")
+	}
+
+	tests := []struct {
+		env  string
+		want bool
+	}{
+		{"1", true},
+		{"yes", true},
+		{"0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv(AccessibleEnv, tt.env)
+			if got := NewSession(nil).Accessible(); got != tt.want {
+				t.Errorf("Accessible() with %s=%q = %v; want %v", AccessibleEnv, tt.env, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package pardon
+
+import (
+	"io"
+	"os"
+
+	"github.com/engmtcdrm/go-pardon/tui"
+)
+
+// Terminal is the input and output abstraction prompts read keystrokes from
+// and render to. Implement it to run prompts over custom streams.
+type Terminal = tui.Terminal
+
+// NewTerminal returns a Terminal reading from in and writing to out, for
+// example NewTerminal(os.Stdin, os.Stderr) to render prompts on stderr.
+func NewTerminal(in, out *os.File) Terminal {
+	return tui.NewTerminal(in, out)
+}
+
+// NewStreamTerminal returns a Terminal over arbitrary streams such as a
+// network connection. Raw mode and size queries are not supported.
+func NewStreamTerminal(r io.Reader, w io.Writer) Terminal {
+	return tui.NewStreamTerminal(r, w)
+}
+
+// NewLineTerminal returns a Terminal over arbitrary streams on which prompts
+// read answers a line at a time, as they do when standard input is not a
+// terminal.
+func NewLineTerminal(r io.Reader, w io.Writer) Terminal {
+	return tui.NewLineTerminal(r, w)
+}
+
+// RestoreTerminal puts the terminal of the active prompt back in its normal
+// state. Prompts do this themselves when they finish, are interrupted or
+// panic; call it when recovering from a panic in another goroutine.
+func RestoreTerminal() {
+	tui.Restore()
+}
+
+// SetSignalsHandled tells prompts whether the application handles SIGINT,
+// SIGTERM and SIGHUP itself, through signal.Notify. A prompt receiving one
+// of them restores the terminal and returns ErrInterrupted; unless the
+// application handles the signal, it is then raised again to end the
+// process as usual.
+func SetSignalsHandled(handled bool) {
+	tui.SetSignalsHandled(handled)
+}
+
+// terminalSetter is implemented by prompts whose terminal can be replaced by a Form.
+type terminalSetter interface {
+	setTerminal(t Terminal)
+}
@@ -0,0 +1,267 @@
+package pardon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon/pardontest"
+)
+
+// keystrokeReader returns one keystroke per Read call so escape sequences
+// are not mistaken for pasted text.
+type keystrokeReader struct {
+	keys []string
+}
+
+func (r *keystrokeReader) Read(p []byte) (int, error) {
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.keys[0])
+	r.keys = r.keys[1:]
+	return n, nil
+}
+
+// rendered returns the screen left by writing out to a virtual terminal.
+func rendered(out []byte) string {
+	term := pardontest.NewTerminal(80, 24)
+	term.Write(out)
+	return term.Screen()
+}
+
+func newTestTerminal(out io.Writer, keys ...string) Terminal {
+	return NewStreamTerminal(&keystrokeReader{keys: keys}, out)
+}
+
+func TestQuestionWithTerminal(t *testing.T) {
+	var out bytes.Buffer
+	var result string
+
+	err := NewQuestion().
+		Title("Name?").
+		Value(&result).
+		Terminal(newTestTerminal(&out, "B", "o", "b", "\r")).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "Bob" {
+		t.Errorf("Ask() value = %q; want %q", result, "Bob")
+	}
+
+	if !strings.Contains(rendered(out.Bytes()), "Name? Bob") {
+		t.Errorf("Output does not contain the answered prompt\nOutput: %q", out.String())
+	}
+}
+
+func TestSelectWithTerminal(t *testing.T) {
+	var out bytes.Buffer
+	var result int
+
+	err := NewSelect[int]().
+		Title("Choose a color:").
+		Options(NewOption("Red", 1), NewOption("Blue", 2), NewOption("Green", 3)).
+		Value(&result).
+		Terminal(newTestTerminal(&out, "\x1b[B", "\x1b[B", "\r")).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != 3 {
+		t.Errorf("Ask() value = %d; want 3", result)
+	}
+
+	if !strings.Contains(rendered(out.Bytes()), "Choose a color: Green") {
+		t.Errorf("Output does not contain the selected answer\nOutput: %q", out.String())
+	}
+}
+
+func TestPromptEndOfInput(t *testing.T) {
+	var result bool
+
+	err := NewConfirm().
+		Title("Continue?").
+		Value(&result).
+		Terminal(newTestTerminal(io.Discard)).
+		Ask()
+
+	if err != ErrEndOfInput {
+		t.Errorf("Ask() error = %v; want %v", err, ErrEndOfInput)
+	}
+}
+
+func TestFormTerminal(t *testing.T) {
+	var out bytes.Buffer
+	var name string
+	var ok bool
+
+	form := NewForm(
+		NewQuestion().Title("Name?").Value(&name),
+		NewConfirm().Title("Continue?").Value(&ok),
+	).Terminal(newTestTerminal(&out, "A", "l", "\r", "y"))
+
+	if err := form.Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if name != "Al" || !ok {
+		t.Errorf("Form values = %q, %t; want %q, %t", name, ok, "Al", true)
+	}
+
+	if !strings.Contains(rendered(out.Bytes()), "Continue? Y") {
+		t.Errorf("Output does not contain the confirmation answer\nOutput: %q", out.String())
+	}
+}
+
+func TestSelectNavigationKeys(t *testing.T) {
+	var result int
+
+	err := NewSelect[int]().
+		Title("Choose a number:").
+		Options(NewOption("One", 1), NewOption("Two", 2), NewOption("Three", 3)).
+		Value(&result).
+		Terminal(newTestTerminal(io.Discard, "\x1b[F", "\x1b[A", "\r")).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != 2 {
+		t.Errorf("Ask() value = %d; want 2", result)
+	}
+}
+
+func TestQuestionMultiByteInput(t *testing.T) {
+	var result string
+
+	// "日本" followed by a backspace, then a combining accent sequence
+	err := NewQuestion().
+		Title("Name?").
+		Value(&result).
+		Terminal(newTestTerminal(io.Discard, "日", "本", "\x7f", "e", "\u0301", "\x7f", "é", "\r")).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "日é" {
+		t.Errorf("Ask() value = %q; want %q", result, "日é")
+	}
+}
+
+func TestPasswordMultiByteInput(t *testing.T) {
+	var result []byte
+
+	err := NewPassword().
+		Title("Password:").
+		Value(&result).
+		Terminal(newTestTerminal(io.Discard, "p", "ä", "ß", "\x7f", "\r")).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if string(result) != "pä" {
+		t.Errorf("Ask() value = %q; want %q", result, "pä")
+	}
+}
+
+func TestQuestionLineEditing(t *testing.T) {
+	var result string
+
+	// Type "wrld", jump home, fix the typo in the middle, then kill a trailing word
+	err := NewQuestion().
+		Title("Greeting?").
+		Value(&result).
+		Terminal(newTestTerminal(io.Discard,
+			"w", "r", "l", "d",
+			"\x01", "\x1b[C", "o",
+			"\x05", " ", "x", "\x17",
+			"\x1b[H", "h", "i", " ",
+			"\x1b[3~",
+			"\r",
+		)).
+		Ask()
+
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "hi orld " {
+		t.Errorf("Ask() value = %q; want %q", result, "hi orld ")
+	}
+}
+
+func TestSelectAskContextDeadline(t *testing.T) {
+	var out bytes.Buffer
+	var result int
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := NewSelect[int]().
+		Title("Choose a color:").
+		Options(NewOption("Red", 1), NewOption("Blue", 2)).
+		Value(&result).
+		Terminal(NewStreamTerminal(r, &out)).
+		AskContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AskContext() error = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	// The title and both options are erased
+	if screen := rendered(out.Bytes()); screen != "" {
+		t.Errorf("Screen after cancel = %q; want the prompt erased", screen)
+	}
+}
+
+func TestQuestionInputAfterCancel(t *testing.T) {
+	var result string
+
+	r, w := io.Pipe()
+	defer w.Close()
+	term := NewStreamTerminal(r, io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := NewQuestion().Title("Name?").Value(&result).Terminal(term).AskContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("AskContext() error = %v; want %v", err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = NewQuestion().Title("Name?").Value(&result).Terminal(term).AskContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AskContext() error = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	// Input typed after the cancelled prompt goes to the next one
+	go w.Write([]byte("Bob\r"))
+
+	if err := NewQuestion().Title("Name?").Value(&result).Terminal(term).Ask(); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+
+	if result != "Bob" {
+		t.Errorf("Ask() value = %q; want %q", result, "Bob")
+	}
+}
@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorLevel is the range of colors a terminal can display.
+type ColorLevel int
+
+const (
+	// ColorNone displays no colors or other text styles.
+	ColorNone ColorLevel = iota
+
+	// Color16 displays the 8 basic colors and their bright variants.
+	Color16
+
+	// Color256 displays the 256 color xterm palette.
+	Color256
+
+	// ColorTrue displays 24-bit RGB colors.
+	ColorTrue
+)
+
+// String returns the name of the color level.
+func (l ColorLevel) String() string {
+	switch l {
+	case ColorNone:
+		return "none"
+	case Color16:
+		return "16"
+	case Color256:
+		return "256"
+	case ColorTrue:
+		return "truecolor"
+	}
+	return "ColorLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// DetectColorLevel returns the colors supported by output going to a
+// terminal, if isTerminal is set, or elsewhere, such as a file or pipe,
+// following the conventions of the environment:
+//
+//   - NO_COLOR set to anything disables colors.
+//   - CLICOLOR_FORCE set to anything but 0 enables colors even if output
+//     is not a terminal, and CLICOLOR=0 disables them otherwise.
+//   - COLORTERM=truecolor or 24bit, or Windows Terminal, enables 24-bit
+//     colors.
+//   - A TERM of dumb disables colors, and one ending in 256color enables
+//     the 256 color palette.
+func DetectColorLevel(isTerminal bool) ColorLevel {
+	return detectColorLevel(os.Getenv, isTerminal)
+}
+
+// detectColorLevel is DetectColorLevel reading the environment from getenv.
+func detectColorLevel(getenv func(string) string, isTerminal bool) ColorLevel {
+	if getenv("NO_COLOR") != "" {
+		return ColorNone
+	}
+
+	forced := getenv("CLICOLOR_FORCE") != "" && getenv("CLICOLOR_FORCE") != "0"
+	if !forced && (!isTerminal || getenv("CLICOLOR") == "0") {
+		return ColorNone
+	}
+
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	if getenv("WT_SESSION") != "" {
+		return ColorTrue
+	}
+
+	term := getenv("TERM")
+	switch {
+	case term == "dumb" && !forced:
+		return ColorNone
+	case strings.HasSuffix(term, "-direct"):
+		return ColorTrue
+	case strings.HasSuffix(term, "256color"):
+		return Color256
+	}
+	return Color16
+}
+
+// ConvertColors rewrites the styles in s for a terminal displaying level.
+// Colors outside its range are replaced with the closest ones it has, and
+// with ColorNone every style is removed. Other escape sequences, such as
+// cursor movement, are kept.
+func ConvertColors(s string, level ColorLevel) string {
+	if level >= ColorTrue || !strings.Contains(s, "\x1b[") {
+		return s
+	}
+
+	var out strings.Builder
+	out.Grow(len(s))
+
+	for {
+		start := strings.Index(s, "\x1b[")
+		if start < 0 {
+			out.WriteString(s)
+			return out.String()
+		}
+		out.WriteString(s[:start])
+		s = s[start:]
+
+		// Find the final byte of the control sequence
+		end := 2
+		for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+			end++
+		}
+		if end == len(s) {
+			out.WriteString(s)
+			return out.String()
+		}
+
+		if s[end] == 'm' && level > ColorNone {
+			out.WriteString(convertSGR(s[2:end], level))
+		} else if s[end] != 'm' {
+			out.WriteString(s[:end+1])
+		}
+		s = s[end+1:]
+	}
+}
+
+// convertSGR returns the select graphic rendition sequence with params,
+// replacing extended colors with ones within level.
+func convertSGR(params string, level ColorLevel) string {
+	codes := strings.Split(params, ";")
+	out := make([]string, 0, len(codes))
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		if (code != "38" && code != "48") || i+1 >= len(codes) {
+			out = append(out, code)
+			continue
+		}
+
+		base := 30
+		if code == "48" {
+			base = 40
+		}
+
+		var r, g, b int
+		switch {
+		case codes[i+1] == "5" && i+2 < len(codes):
+			n := atoiColor(codes[i+2])
+			i += 2
+			if level >= Color256 {
+				out = append(out, code, "5", strconv.Itoa(n))
+				continue
+			}
+			if n < 16 {
+				out = append(out, basicColor(n, base))
+				continue
+			}
+			r, g, b = paletteRGB(n)
+		case codes[i+1] == "2" && i+4 < len(codes):
+			r, g, b = atoiColor(codes[i+2]), atoiColor(codes[i+3]), atoiColor(codes[i+4])
+			i += 4
+		default:
+			out = append(out, code)
+			continue
+		}
+
+		if level >= Color256 {
+			out = append(out, code, "5", strconv.Itoa(nearest256(r, g, b)))
+		} else {
+			out = append(out, basicColor(nearest16(r, g, b), base))
+		}
+	}
+
+	return "\x1b[" + strings.Join(out, ";") + "m"
+}
+
+// atoiColor parses a color component, treating anything invalid as 0.
+func atoiColor(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return min(n, 255)
+}
+
+// basicColor returns the code of basic color n, from 0 to 15, as a
+// foreground color for base 30 or a background color for base 40.
+func basicColor(n, base int) string {
+	if n >= 8 {
+		return strconv.Itoa(base + 60 + n - 8)
+	}
+	return strconv.Itoa(base + n)
+}
+
+// basicPalette holds the colors xterm displays for the 16 basic colors.
+var basicPalette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels holds the component values of the 6x6x6 color cube.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// paletteRGB returns the color of entry n in the 256 color palette.
+func paletteRGB(n int) (int, int, int) {
+	switch {
+	case n < 16:
+		c := basicPalette[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+}
+
+// nearest256 returns the entry of the 256 color palette closest to the
+// color, choosing between the color cube and the grayscale ramp.
+func nearest256(r, g, b int) int {
+	cube := 16 + 36*nearestLevel(r) + 6*nearestLevel(g) + nearestLevel(b)
+
+	gray := 232 + min(max((r+g+b)/3-8+5, 0)/10, 23)
+	if colorDistance(r, g, b, gray) < colorDistance(r, g, b, cube) {
+		return gray
+	}
+	return cube
+}
+
+// nearestLevel returns the index of the color cube level closest to v.
+func nearestLevel(v int) int {
+	best := 0
+	for i, level := range cubeLevels {
+		if abs(v-level) < abs(v-cubeLevels[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// nearest16 returns the basic color closest to the color.
+func nearest16(r, g, b int) int {
+	best := 0
+	for n := range basicPalette {
+		if colorDistance(r, g, b, n) < colorDistance(r, g, b, best) {
+			best = n
+		}
+	}
+	return best
+}
+
+// colorDistance returns the squared distance between a color and entry n
+// of the 256 color palette.
+func colorDistance(r, g, b, n int) int {
+	pr, pg, pb := paletteRGB(n)
+	return (r-pr)*(r-pr) + (g-pg)*(g-pg) + (b-pb)*(b-pb)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
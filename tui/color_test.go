@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/engmtcdrm/go-ansi"
+)
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		isTerminal bool
+		want       ColorLevel
+	}{
+		{"basic terminal", map[string]string{"TERM": "xterm"}, true, Color16},
+		{"256 color terminal", map[string]string{"TERM": "xterm-256color"}, true, Color256},
+		{"truecolor terminal", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, ColorTrue},
+		{"24bit terminal", map[string]string{"COLORTERM": "24bit"}, true, ColorTrue},
+		{"direct color terminfo", map[string]string{"TERM": "xterm-direct"}, true, ColorTrue},
+		{"windows terminal", map[string]string{"WT_SESSION": "1"}, true, ColorTrue},
+		{"dumb terminal", map[string]string{"TERM": "dumb"}, true, ColorNone},
+		{"not a terminal", map[string]string{"TERM": "xterm-256color"}, false, ColorNone},
+		{"no color", map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, true, ColorNone},
+		{"clicolor off", map[string]string{"CLICOLOR": "0", "TERM": "xterm"}, true, ColorNone},
+		{"forced when not a terminal", map[string]string{"CLICOLOR_FORCE": "1", "TERM": "xterm-256color"}, false, Color256},
+		{"forced on dumb terminal", map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"}, true, Color16},
+		{"force of zero", map[string]string{"CLICOLOR_FORCE": "0"}, false, ColorNone},
+		{"no color beats force", map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, true, ColorNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectColorLevel(getenv, tt.isTerminal); got != tt.want {
+				t.Errorf("detectColorLevel() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertColors(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		level ColorLevel
+		want  string
+	}{
+		{"plain text", "hello", ColorNone, "hello"},
+		{"truecolor unchanged", ansi.Foreground24Bit(255, 0, 0) + "x", ColorTrue, ansi.Foreground24Bit(255, 0, 0) + "x"},
+		{"styles stripped", ansi.Red + "error" + ansi.Reset, ColorNone, "error"},
+		{"cursor movement kept", ansi.Bold + "a" + ansi.CursorUp(2) + "b", ColorNone, "a" + ansi.CursorUp(2) + "b"},
+		{"basic color kept", ansi.Red + "x", Color16, ansi.Red + "x"},
+		{"palette kept on 256", "\x1b[38;5;208mx", Color256, "\x1b[38;5;208mx"},
+		{"palette to basic", "\x1b[38;5;9mx", Color16, "\x1b[91mx"},
+		{"palette cube to basic", "\x1b[38;5;21mx", Color16, "\x1b[34mx"},
+		{"rgb to palette", "\x1b[38;2;255;135;0mx", Color256, "\x1b[38;5;208mx"},
+		{"rgb gray to palette", "\x1b[38;2;128;128;128mx", Color256, "\x1b[38;5;244mx"},
+		{"rgb to basic", "\x1b[38;2;250;10;10mx", Color16, "\x1b[91mx"},
+		{"rgb background to basic", "\x1b[48;2;0;0;0mx", Color16, "\x1b[40mx"},
+		{"combined with other codes", "\x1b[1;38;2;0;200;0;4mx", Color16, "\x1b[1;32;4mx"},
+		{"incomplete sequence", "a\x1b[3", Color16, "a\x1b[3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertColors(tt.s, tt.level); got != tt.want {
+				t.Errorf("ConvertColors(%q, %v) = %q; want %q", tt.s, tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileTerminalConvertsColors(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "1")
+	t.Setenv("COLORTERM", "")
+	t.Setenv("WT_SESSION", "")
+	t.Setenv("TERM", "xterm")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	term := NewTerminal(os.Stdin, w)
+	if _, err := io.WriteString(term, ansi.Foreground24Bit(255, 0, 0)+"x"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	w.Close()
+
+	got, _ := io.ReadAll(r)
+	if want := "\x1b[91mx"; string(got) != want {
+		t.Errorf("Written output = %q; want %q", got, want)
+	}
+}
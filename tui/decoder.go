@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// tildeKeys maps the numeric parameter of "ESC [ n ~" sequences to keys.
+var tildeKeys = map[int]keys.Key{
+	1:  keys.Home,
+	2:  keys.Insert,
+	3:  keys.Delete,
+	4:  keys.End,
+	5:  keys.PageUp,
+	6:  keys.PageDown,
+	7:  keys.Home,
+	8:  keys.End,
+	11: keys.F1,
+	12: keys.F2,
+	13: keys.F3,
+	14: keys.F4,
+	15: keys.F5,
+	17: keys.F6,
+	18: keys.F7,
+	19: keys.F8,
+	20: keys.F9,
+	21: keys.F10,
+	23: keys.F11,
+	24: keys.F12,
+}
+
+// finalKeys maps the final byte of CSI and SS3 sequences to keys.
+var finalKeys = map[byte]keys.Key{
+	'A': keys.Up,
+	'B': keys.Down,
+	'C': keys.Right,
+	'D': keys.Left,
+	'H': keys.Home,
+	'F': keys.End,
+	'P': keys.F1,
+	'Q': keys.F2,
+	'R': keys.F3,
+	'S': keys.F4,
+}
+
+// Decoder converts raw terminal input into key events. Input may be fed in
+// arbitrary chunks; sequences split across chunks are held until complete.
+type Decoder struct {
+	buf    []byte
+	skipLF bool // Drop a line feed completing a CRLF split by Line
+}
+
+// Feed appends raw input bytes to the decoder.
+func (d *Decoder) Feed(b []byte) {
+	d.buf = append(d.buf, b...)
+}
+
+// Pending reports whether undecoded input is buffered.
+func (d *Decoder) Pending() bool {
+	return len(d.buf) > 0
+}
+
+// Line removes the next line of buffered input and returns it without its
+// line ending, which may be LF, CRLF or CR. It returns false when no complete
+// line is buffered; when final is true, trailing input without a line ending
+// is returned as the last line.
+func (d *Decoder) Line(final bool) (string, bool) {
+	d.dropLF()
+
+	i := bytes.IndexAny(d.buf, "\r\n")
+	if i < 0 {
+		if !final || len(d.buf) == 0 {
+			return "", false
+		}
+		i = len(d.buf)
+	}
+
+	line := string(d.buf[:i])
+	if i < len(d.buf) {
+		if d.buf[i] == '\r' {
+			d.skipLF = true
+		}
+		i++
+	}
+	d.buf = d.buf[i:]
+	d.dropLF()
+
+	return line, true
+}
+
+// dropLF drops the line feed completing a CRLF once input after the CR is
+// buffered, so it isn't left over when nothing more is read.
+func (d *Decoder) dropLF() {
+	if d.skipLF && len(d.buf) > 0 {
+		d.skipLF = false
+		if d.buf[0] == '\n' {
+			d.buf = d.buf[1:]
+		}
+	}
+}
+
+// Next decodes the next key event from the buffered input. It returns false
+// when no complete event is available. When final is true, an incomplete
+// sequence at the end of the input is resolved as best as possible instead of
+// waiting for more bytes; most importantly a lone ESC becomes Escape.
+func (d *Decoder) Next(final bool) (keys.Event, bool) {
+	for len(d.buf) > 0 {
+		ev, n, ok := decode(d.buf, final)
+		if n == 0 {
+			// Incomplete sequence, wait for more input
+			return keys.Event{}, false
+		}
+
+		d.buf = d.buf[n:]
+		if ok {
+			return ev, true
+		}
+		// Consumed an unrecognised sequence, keep going
+	}
+
+	return keys.Event{}, false
+}
+
+// decode decodes a single event from the start of b. It returns the number of
+// bytes consumed, which is zero if b holds an incomplete sequence, and false
+// if the consumed bytes did not form a recognised key.
+func decode(b []byte, final bool) (keys.Event, int, bool) {
+	c := b[0]
+
+	if c == keys.KeyEscape {
+		return decodeEscape(b, final)
+	}
+
+	if c < utf8.RuneSelf {
+		ev, ok := decodeByte(c)
+		return ev, 1, ok
+	}
+
+	if !utf8.FullRune(b) && !final {
+		return keys.Event{}, 0, false
+	}
+
+	r, size := utf8.DecodeRune(b)
+	if r == utf8.RuneError {
+		return keys.Event{}, size, false
+	}
+
+	return keys.Char(r), size, true
+}
+
+// decodeByte decodes a single ASCII byte.
+func decodeByte(c byte) (keys.Event, bool) {
+	switch {
+	case c == keys.KeyEnter || c == keys.KeyCarriageReturn:
+		return keys.Named(keys.Enter), true
+	case c == '\t':
+		return keys.Named(keys.Tab), true
+	case c == keys.KeyBackspace || c == keys.KeyDelete:
+		return keys.Named(keys.Backspace), true
+	case c == keys.KeyEscape:
+		return keys.Named(keys.Escape), true
+	case c == 0:
+		return keys.Ctrl(' '), true
+	case c < 27:
+		return keys.Ctrl(rune('a' + c - 1)), true
+	case c < 32:
+		// Ctrl+\, Ctrl+], Ctrl+^ and Ctrl+_
+		return keys.Ctrl(rune('\\' + c - 28)), true
+	default:
+		return keys.Char(rune(c)), true
+	}
+}
+
+// decodeEscape decodes a sequence starting with ESC.
+func decodeEscape(b []byte, final bool) (keys.Event, int, bool) {
+	if len(b) == 1 {
+		if !final {
+			return keys.Event{}, 0, false
+		}
+		return keys.Named(keys.Escape), 1, true
+	}
+
+	switch b[1] {
+	case keys.KeyLeftBracket:
+		if ev, n, ok := decodeCSI(b); n > 0 || !final {
+			return ev, n, ok
+		}
+	case 'O':
+		if len(b) >= 3 {
+			if k, ok := finalKeys[b[2]]; ok {
+				return keys.Named(k), 3, true
+			}
+			return keys.Event{}, 3, false
+		}
+		if !final {
+			return keys.Event{}, 0, false
+		}
+	}
+
+	// ESC followed by another key is that key with Alt held
+	ev, n, ok := decode(b[1:], final)
+	if n == 0 {
+		return ev, 0, false
+	}
+	if ev.Key == keys.Escape {
+		// Two escapes in a row are two separate key presses
+		return keys.Named(keys.Escape), 1, true
+	}
+	ev.Mod |= keys.ModAlt
+	return ev, n + 1, ok
+}
+
+// decodeCSI decodes a control sequence of the form "ESC [ params final".
+func decodeCSI(b []byte) (keys.Event, int, bool) {
+	i := 2
+	for i < len(b) && b[i] >= 0x20 && b[i] <= 0x3f {
+		i++
+	}
+	if i >= len(b) {
+		return keys.Event{}, 0, false
+	}
+
+	n := i + 1
+	final := b[i]
+	if final < 0x40 || final > 0x7e {
+		// Malformed sequence, drop the introducer
+		return keys.Event{}, 2, false
+	}
+
+	params := strings.Split(string(b[2:i]), ";")
+	param := func(idx, def int) int {
+		if idx >= len(params) {
+			return def
+		}
+		v, err := strconv.Atoi(params[idx])
+		if err != nil {
+			return def
+		}
+		return v
+	}
+
+	mod := modifiers(param(1, 1))
+
+	switch final {
+	case '~':
+		k, ok := tildeKeys[param(0, 0)]
+		if !ok {
+			// Includes bracketed paste markers, which carry no key
+			return keys.Event{}, n, false
+		}
+		return keys.Named(k, mod), n, true
+	case 'Z':
+		return keys.Named(keys.Tab, keys.ModShift), n, true
+	}
+
+	if k, ok := finalKeys[final]; ok {
+		return keys.Named(k, mod), n, true
+	}
+
+	return keys.Event{}, n, false
+}
+
+// modifiers converts an xterm modifier parameter into a set of modifiers.
+func modifiers(param int) keys.Mod {
+	if param <= 1 {
+		return 0
+	}
+
+	bits := param - 1
+	var mod keys.Mod
+	if bits&1 != 0 {
+		mod |= keys.ModShift
+	}
+	if bits&2 != 0 {
+		mod |= keys.ModAlt
+	}
+	if bits&4 != 0 {
+		mod |= keys.ModCtrl
+	}
+	return mod
+}
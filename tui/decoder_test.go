@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// decodeAll decodes input as a single final chunk.
+func decodeAll(input string) []keys.Event {
+	var d Decoder
+	d.Feed([]byte(input))
+
+	var events []keys.Event
+	for {
+		ev, ok := d.Next(true)
+		if !ok {
+			return events
+		}
+		events = append(events, ev)
+	}
+}
+
+func TestDecoderSingleKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  keys.Event
+	}{
+		{"printable", "a", keys.Char('a')},
+		{"uppercase", "A", keys.Char('A')},
+		{"space", " ", keys.Char(' ')},
+		{"multi-byte rune", "é", keys.Char('é')},
+		{"wide rune", "日", keys.Char('日')},
+		{"carriage return", "\r", keys.Named(keys.Enter)},
+		{"line feed", "\n", keys.Named(keys.Enter)},
+		{"tab", "\t", keys.Named(keys.Tab)},
+		{"backspace", "\x7f", keys.Named(keys.Backspace)},
+		{"ctrl+h", "\x08", keys.Named(keys.Backspace)},
+		{"ctrl+a", "\x01", keys.Ctrl('a')},
+		{"ctrl+c", "\x03", keys.Ctrl('c')},
+		{"ctrl+w", "\x17", keys.Ctrl('w')},
+		{"ctrl+space", "\x00", keys.Ctrl(' ')},
+		{"ctrl+underscore", "\x1f", keys.Ctrl('_')},
+		{"lone escape", "\x1b", keys.Named(keys.Escape)},
+		{"up", "\x1b[A", keys.Named(keys.Up)},
+		{"down", "\x1b[B", keys.Named(keys.Down)},
+		{"right", "\x1b[C", keys.Named(keys.Right)},
+		{"left", "\x1b[D", keys.Named(keys.Left)},
+		{"application mode up", "\x1bOA", keys.Named(keys.Up)},
+		{"home", "\x1b[H", keys.Named(keys.Home)},
+		{"end", "\x1b[F", keys.Named(keys.End)},
+		{"home tilde", "\x1b[1~", keys.Named(keys.Home)},
+		{"end tilde", "\x1b[4~", keys.Named(keys.End)},
+		{"home rxvt", "\x1b[7~", keys.Named(keys.Home)},
+		{"end ss3", "\x1bOF", keys.Named(keys.End)},
+		{"insert", "\x1b[2~", keys.Named(keys.Insert)},
+		{"delete", "\x1b[3~", keys.Named(keys.Delete)},
+		{"page up", "\x1b[5~", keys.Named(keys.PageUp)},
+		{"page down", "\x1b[6~", keys.Named(keys.PageDown)},
+		{"f1", "\x1bOP", keys.Named(keys.F1)},
+		{"f4", "\x1bOS", keys.Named(keys.F4)},
+		{"f5", "\x1b[15~", keys.Named(keys.F5)},
+		{"f12", "\x1b[24~", keys.Named(keys.F12)},
+		{"shift+tab", "\x1b[Z", keys.Named(keys.Tab, keys.ModShift)},
+		{"shift+up", "\x1b[1;2A", keys.Named(keys.Up, keys.ModShift)},
+		{"ctrl+right", "\x1b[1;5C", keys.Named(keys.Right, keys.ModCtrl)},
+		{"alt+shift+left", "\x1b[1;4D", keys.Named(keys.Left, keys.ModAlt, keys.ModShift)},
+		{"ctrl+delete", "\x1b[3;5~", keys.Named(keys.Delete, keys.ModCtrl)},
+		{"shift+f1", "\x1b[1;2P", keys.Named(keys.F1, keys.ModShift)},
+		{"alt+b", "\x1bb", keys.Alt('b')},
+		{"alt+backspace", "\x1b\x7f", keys.Named(keys.Backspace, keys.ModAlt)},
+		{"alt+enter", "\x1b\r", keys.Named(keys.Enter, keys.ModAlt)},
+		{"alt+up", "\x1b\x1b[A", keys.Named(keys.Up, keys.ModAlt)},
+		{"alt+rune", "\x1bé", keys.Event{Key: keys.Rune, Rune: 'é', Mod: keys.ModAlt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := decodeAll(tt.input)
+			if len(events) != 1 {
+				t.Fatalf("Decoded %q into %d events %v; want 1", tt.input, len(events), events)
+			}
+			if events[0] != tt.want {
+				t.Errorf("Decoded %q = %v; want %v", tt.input, events[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoderMultipleKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []keys.Event
+	}{
+		{"paste", "hey", []keys.Event{keys.Char('h'), keys.Char('e'), keys.Char('y')}},
+		{"double escape", "\x1b\x1b", []keys.Event{keys.Named(keys.Escape), keys.Named(keys.Escape)}},
+		{"arrows then text", "\x1b[B\x1b[Bx", []keys.Event{keys.Named(keys.Down), keys.Named(keys.Down), keys.Char('x')}},
+		{"bracketed paste markers", "\x1b[200~ab\x1b[201~", []keys.Event{keys.Char('a'), keys.Char('b')}},
+		{"unknown sequence skipped", "\x1b[99xq", []keys.Event{keys.Char('q')}},
+		{"invalid utf-8 skipped", "\xffz", []keys.Event{keys.Char('z')}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := decodeAll(tt.input)
+			if len(events) != len(tt.want) {
+				t.Fatalf("Decoded %q into %v; want %v", tt.input, events, tt.want)
+			}
+			for i := range events {
+				if events[i] != tt.want[i] {
+					t.Errorf("Event %d of %q = %v; want %v", i, tt.input, events[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecoderSplitReads(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   keys.Event
+	}{
+		{"csi split after introducer", []string{"\x1b[", "A"}, keys.Named(keys.Up)},
+		{"csi split after escape", []string{"\x1b", "[B"}, keys.Named(keys.Down)},
+		{"csi split in parameters", []string{"\x1b[1", "5~"}, keys.Named(keys.F5)},
+		{"utf-8 split", []string{"\xe6\x97", "\xa5"}, keys.Char('日')},
+		{"ss3 split", []string{"\x1bO", "P"}, keys.Named(keys.F1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Decoder
+
+			for _, chunk := range tt.chunks[:len(tt.chunks)-1] {
+				d.Feed([]byte(chunk))
+				if ev, ok := d.Next(false); ok {
+					t.Fatalf("Decoded %v from incomplete input %q", ev, chunk)
+				}
+				if !d.Pending() {
+					t.Fatalf("Incomplete input %q was not kept", chunk)
+				}
+			}
+
+			d.Feed([]byte(tt.chunks[len(tt.chunks)-1]))
+			ev, ok := d.Next(false)
+			if !ok || ev != tt.want {
+				t.Errorf("Decoded %v (ok %t); want %v", ev, ok, tt.want)
+			}
+			if d.Pending() {
+				t.Error("Decoder still has pending input")
+			}
+		})
+	}
+}
+
+func TestDecoderFinalResolvesIncomplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  keys.Event
+	}{
+		{"lone escape", "\x1b", keys.Named(keys.Escape)},
+		{"alt+[", "\x1b[", keys.Alt('[')},
+		{"alt+O", "\x1bO", keys.Alt('O')},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Decoder
+			d.Feed([]byte(tt.input))
+
+			if _, ok := d.Next(false); ok {
+				t.Fatal("Incomplete input decoded without final flag")
+			}
+
+			ev, ok := d.Next(true)
+			if !ok || ev != tt.want {
+				t.Errorf("Next(true) = %v (ok %t); want %v", ev, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoderLine(t *testing.T) {
+	var d Decoder
+	d.Feed([]byte("one\ntwo\r\nthree\rfour"))
+
+	for _, want := range []string{"one", "two", "three"} {
+		line, ok := d.Line(false)
+		if !ok || line != want {
+			t.Fatalf("Line(false) = %q, %t; want %q, true", line, ok, want)
+		}
+	}
+
+	if line, ok := d.Line(false); ok {
+		t.Fatalf("Line(false) = %q; want no line until the line ends", line)
+	}
+
+	// A CRLF split across reads is a single line ending
+	d.Feed([]byte("\r"))
+	if line, ok := d.Line(false); !ok || line != "four" {
+		t.Fatalf("Line(false) = %q, %t; want %q, true", line, ok, "four")
+	}
+	d.Feed([]byte("\nfive"))
+	if line, ok := d.Line(true); !ok || line != "five" {
+		t.Fatalf("Line(true) = %q, %t; want %q, true", line, ok, "five")
+	}
+
+	if _, ok := d.Line(true); ok {
+		t.Error("Line(true) returned a line from empty input")
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// DefaultEditor returns the editor command set by the VISUAL or EDITOR
+// environment variables, in that order, falling back to notepad on Windows
+// and vi elsewhere.
+func DefaultEditor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// RunEditor opens path in editor and waits for it to exit. The editor is a
+// program followed by any arguments to it, separated by spaces, such as
+// "code --wait". It runs on the files behind t, or writes its output to t
+// if it has none. The editor is killed once ctx is done, in which case the
+// error wraps ctx.Err().
+func RunEditor(ctx context.Context, t Terminal, editor, path string) error {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return errors.New("no editor set")
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], append(args[1:], path)...)
+	if ft, ok := t.(*fileTerminal); ok {
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = ft.in, ft.out, ft.out
+	} else {
+		cmd.Stdout, cmd.Stderr = t, t
+	}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return canceled(ctx.Err())
+		}
+		return fmt.Errorf("running editor: %w", err)
+	}
+	return nil
+}
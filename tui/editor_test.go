@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDefaultEditor(t *testing.T) {
+	fallback := "vi"
+	if runtime.GOOS == "windows" {
+		fallback = "notepad"
+	}
+
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   string
+	}{
+		{"visual first", "code --wait", "nano", "code --wait"},
+		{"editor", "", "nano", "nano"},
+		{"blank visual", "  ", "nano", "nano"},
+		{"fallback", "", "", fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			if got := DefaultEditor(); got != tt.want {
+				t.Errorf("DefaultEditor() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
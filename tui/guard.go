@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// guardState tracks the terminals of active prompts so they can be put back
+// in order when the process is interrupted, terminated, suspended or panics.
+type guardState struct {
+	mu         sync.Mutex
+	active     map[any]*guarded // Keyed by terminalKey, or the guarded itself
+	selfStop   *guarded         // Terminal suspending the process from Ctrl-Z, which reports its own resume
+	stopSignal func()
+	handled    bool // The application handles interrupt and termination signals itself
+}
+
+// guarded is the state of a single terminal in use by a prompt.
+type guarded struct {
+	term        Terminal
+	key         any
+	users       int
+	raw         func() error // Restores the terminal from raw mode
+	rawActive   bool
+	resumed     chan struct{}
+	interrupted chan struct{}
+}
+
+// guard is the state of the terminals in use.
+var guard = guardState{active: make(map[any]*guarded)}
+
+// terminalKey returns t as a map key, or nil if it can't be one. Terminals
+// that can't be compared, such as structs holding a slice, can't be told
+// apart from their copies, so each use of one is tracked on its own.
+func terminalKey(t Terminal) any {
+	if v := reflect.ValueOf(t); v.IsValid() && v.Comparable() {
+		return t
+	}
+	return nil
+}
+
+// suspender is implemented by terminals that can suspend the process, as
+// happens when Ctrl-Z is pressed in a shell with job control. Suspend
+// returns once the process has been resumed.
+type suspender interface {
+	Suspend() error
+}
+
+// Guard protects t while a prompt is active on it. Until the returned
+// function is called, interrupt, termination and hangup signals restore the
+// terminal and end the prompt with ErrInterrupted before taking their usual
+// effect (see SetSignalsHandled), and the process can be suspended and
+// resumed, after which the prompt receives a keys.Resume event. The returned function must be deferred: if the prompt panics, it
+// restores the terminal before the panic continues.
+func Guard(t Terminal) func() {
+	gt := guard.enter(t)
+
+	return func() {
+		if r := recover(); r != nil {
+			guard.restore(gt)
+			guard.leave(gt)
+			panic(r)
+		}
+		guard.leave(gt)
+	}
+}
+
+// Restore puts the terminals of all active prompts back in their normal
+// state, leaving raw mode and showing the cursor. Call it when recovering
+// from a panic in another goroutine while a prompt is active.
+func Restore() {
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	for _, gt := range guard.active {
+		gt.restore()
+	}
+}
+
+// SetSignalsHandled tells the guard whether the application handles
+// interrupt, termination and hangup signals itself, through signal.Notify.
+// Either way a prompt receiving one restores its terminal and returns
+// ErrInterrupted, but the signal is only raised again, to take its usual
+// effect of ending the process, when the application doesn't handle it.
+func SetSignalsHandled(handled bool) {
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	guard.handled = handled
+}
+
+// enter starts guarding t, watching for signals while any terminal is
+// guarded. Prompts on the same t share the returned handle, unless t can't
+// be a map key.
+func (g *guardState) enter(t Terminal) *guarded {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := terminalKey(t)
+	gt, ok := g.active[key]
+	if key == nil || !ok {
+		gt = &guarded{term: t, key: key, resumed: make(chan struct{}, 1), interrupted: make(chan struct{}, 1)}
+		if key == nil {
+			gt.key = gt
+		}
+		g.active[gt.key] = gt
+	}
+	gt.users++
+
+	if g.stopSignal == nil {
+		g.stopSignal = watchSignals()
+	}
+	return gt
+}
+
+// leave stops guarding the terminal of gt once every prompt on it has
+// finished.
+func (g *guardState) leave(gt *guarded) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gt.users--
+	if gt.users > 0 {
+		return
+	}
+
+	if g.active[gt.key] == gt {
+		delete(g.active, gt.key)
+	}
+	if g.selfStop == gt {
+		g.selfStop = nil
+	}
+	if len(g.active) == 0 && g.stopSignal != nil {
+		g.stopSignal()
+		g.stopSignal = nil
+	}
+}
+
+// restore puts the terminal of gt back in its normal state.
+func (g *guardState) restore(gt *guarded) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gt.restore()
+}
+
+// restore leaves raw mode and shows the cursor. guard.mu must be held.
+func (gt *guarded) restore() {
+	if gt.rawActive {
+		gt.raw()
+		gt.rawActive = false
+	}
+	fmt.Fprint(gt.term, ansi.ShowCursor)
+}
+
+// setRaw records that the terminal of gt is in raw mode until restore is
+// called.
+func (g *guardState) setRaw(gt *guarded, restore func() error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gt.raw = restore
+	gt.rawActive = true
+}
+
+// leaveRaw restores the terminal of gt from raw mode, unless that already
+// happened, and forgets the restore function.
+func (g *guardState) leaveRaw(gt *guarded) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if gt.rawActive {
+		gt.raw()
+	}
+	gt.raw = nil
+	gt.rawActive = false
+}
+
+// reenterRaw puts the terminal of gt back into raw mode after the process
+// was resumed, keeping the original restore function as the shell may have
+// left the terminal in any state.
+func (g *guardState) reenterRaw(gt *guarded) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if gt.raw == nil || gt.rawActive {
+		return
+	}
+
+	if _, err := gt.term.MakeRaw(); err == nil {
+		gt.rawActive = true
+	}
+}
+
+// resume reports that the process was continued after being stopped to
+// every guarded terminal, except one that suspended it from Ctrl-Z.
+func (g *guardState) resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, gt := range g.active {
+		if gt == g.selfStop {
+			continue
+		}
+
+		select {
+		case gt.resumed <- struct{}{}:
+		default:
+		}
+	}
+	g.selfStop = nil
+}
+
+// interrupt restores every guarded terminal and tells the prompts on them
+// to end. It reports whether the application handles the signal itself.
+func (g *guardState) interrupt() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, gt := range g.active {
+		gt.restore()
+
+		select {
+		case gt.interrupted <- struct{}{}:
+		default:
+		}
+	}
+	return g.handled
+}
+
+// interruption returns the channel told when a signal interrupts the
+// prompt on t, or nil if t is not guarded or can't be looked up.
+func (g *guardState) interruption(t Terminal) <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if key := terminalKey(t); key != nil {
+		if gt, ok := g.active[key]; ok {
+			return gt.interrupted
+		}
+	}
+	return nil
+}
+
+// suspend restores the terminal of gt and suspends the process through s,
+// returning the event telling the prompt to draw itself again.
+func (g *guardState) suspend(gt *guarded, s suspender) keys.Event {
+	g.mu.Lock()
+	gt.restore()
+	g.selfStop = gt
+	g.mu.Unlock()
+
+	s.Suspend()
+	g.reenterRaw(gt)
+
+	return keys.Named(keys.Resume)
+}
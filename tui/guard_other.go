@@ -0,0 +1,9 @@
+//go:build !unix
+
+package tui
+
+// watchSignals does nothing on systems without job control signals; the
+// terminal is still restored when a prompt panics.
+func watchSignals() func() {
+	return func() {}
+}
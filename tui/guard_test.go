@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// guardTerminal records raw mode and suspends over in-memory streams.
+type guardTerminal struct {
+	streamTerminal
+	raw       bool
+	raws      int
+	suspended int
+}
+
+func newGuardTerminal(input string) (*guardTerminal, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &guardTerminal{streamTerminal: streamTerminal{strings.NewReader(input), &out}}, &out
+}
+
+func (t *guardTerminal) MakeRaw() (func() error, error) {
+	t.raw = true
+	t.raws++
+	return func() error { t.raw = false; return nil }, nil
+}
+
+func (t *guardTerminal) Suspend() error {
+	if t.raw {
+		panic("suspended while in raw mode")
+	}
+	t.suspended++
+	return nil
+}
+
+// valueTerminal is a Terminal used by value, which its slice keeps from
+// being a map key.
+type valueTerminal struct {
+	io.Reader
+	io.Writer
+	tags []string
+}
+
+func (valueTerminal) MakeRaw() (func() error, error) { return func() error { return nil }, nil }
+
+func (valueTerminal) Size() (int, int, error) { return 0, 0, ErrNotTerminal }
+
+func TestGuardValueTerminal(t *testing.T) {
+	var out bytes.Buffer
+	term := valueTerminal{Reader: strings.NewReader(""), Writer: &out, tags: []string{"a"}}
+
+	leave := Guard(term)
+	Restore()
+	if ch := guard.interruption(term); ch != nil {
+		t.Error("interruption() found a terminal that can't be looked up")
+	}
+	leave()
+
+	if out.String() != ansi.ShowCursor {
+		t.Errorf("Output = %q; want %q", out.String(), ansi.ShowCursor)
+	}
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	for _, gt := range guard.active {
+		if _, ok := gt.term.(valueTerminal); ok {
+			t.Error("Guard still active after leaving")
+		}
+	}
+}
+
+func TestReadValueTerminal(t *testing.T) {
+	term := valueTerminal{Reader: strings.NewReader("xname\n"), Writer: io.Discard, tags: []string{"a"}}
+	defer Guard(term)()
+
+	if ev, err := ReadKey(term); err != nil || ev != keys.Char('x') {
+		t.Fatalf("ReadKey() = %v, %v; want %v", ev, err, keys.Char('x'))
+	}
+
+	// Input left over can't be found again, so it is dropped
+	if _, err := ReadLine(context.Background(), term); !errors.Is(err, ErrEndOfInput) {
+		t.Errorf("ReadLine() error = %v; want %v", err, ErrEndOfInput)
+	}
+
+	inputs.Lock()
+	defer inputs.Unlock()
+	for _, in := range inputs.m {
+		if _, ok := in.term.(valueTerminal); ok {
+			t.Error("Input kept after reading")
+		}
+	}
+}
+
+func TestGuardRestoresOnPanic(t *testing.T) {
+	term, out := newGuardTerminal("")
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recover() = %v; want boom", r)
+			}
+		}()
+		defer Guard(term)()
+		gt := guard.enter(term)
+		defer guard.leave(gt)
+
+		restore, _ := term.MakeRaw()
+		guard.setRaw(gt, restore)
+		panic("boom")
+	}()
+
+	if term.raw {
+		t.Error("Terminal still in raw mode after a panic")
+	}
+	if !strings.HasSuffix(out.String(), ansi.ShowCursor) {
+		t.Errorf("Output = %q; want cursor shown", out.String())
+	}
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	if _, ok := guard.active[term]; ok {
+		t.Error("Guard still active after a panic")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	term, out := newGuardTerminal("")
+	gt := guard.enter(term)
+	defer guard.leave(gt)
+
+	restore, _ := term.MakeRaw()
+	guard.setRaw(gt, restore)
+	defer guard.leaveRaw(gt)
+
+	Restore()
+
+	if term.raw {
+		t.Error("Restore() left the terminal in raw mode")
+	}
+	if out.String() != ansi.ShowCursor {
+		t.Errorf("Output = %q; want %q", out.String(), ansi.ShowCursor)
+	}
+}
+
+func TestReadKeySuspend(t *testing.T) {
+	term, _ := newGuardTerminal("\x1ax")
+	defer Guard(term)()
+
+	ev, err := ReadKey(term)
+	if err != nil {
+		t.Fatalf("ReadKey() error = %v", err)
+	}
+	if ev.Key != keys.Resume {
+		t.Errorf("ReadKey() = %v; want %v", ev, keys.Named(keys.Resume))
+	}
+	if term.suspended != 1 {
+		t.Errorf("Suspend() called %d times; want 1", term.suspended)
+	}
+
+	ev, err = ReadKey(term)
+	if err != nil {
+		t.Fatalf("ReadKey() error = %v", err)
+	}
+	if ev != keys.Char('x') {
+		t.Errorf("ReadKey() after resume = %v; want %v", ev, keys.Char('x'))
+	}
+}
+
+func TestReadKeyInterrupted(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	term := &guardTerminal{streamTerminal: streamTerminal{r, io.Discard}}
+	defer Guard(term)()
+
+	reader := NewKeyReader(term)
+	defer reader.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := reader.ReadKey(context.Background())
+		done <- err
+	}()
+
+	SetSignalsHandled(true)
+	defer SetSignalsHandled(false)
+	if !guard.interrupt() {
+		t.Error("interrupt() = false; want the signal left to the application")
+	}
+
+	if err := <-done; !errors.Is(err, ErrInterrupted) {
+		t.Errorf("ReadKey() error = %v; want %v", err, ErrInterrupted)
+	}
+	if term.raw {
+		t.Error("Terminal still in raw mode after an interrupt")
+	}
+}
+
+func TestReadLineInterrupted(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	term := &guardTerminal{streamTerminal: streamTerminal{r, io.Discard}}
+	defer Guard(term)()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ReadLine(context.Background(), term)
+		done <- err
+	}()
+
+	// The line reader may not be waiting yet, so the interrupt is kept for it
+	guard.interrupt()
+
+	if err := <-done; !errors.Is(err, ErrInterrupted) {
+		t.Errorf("ReadLine() error = %v; want %v", err, ErrInterrupted)
+	}
+}
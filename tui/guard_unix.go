@@ -0,0 +1,57 @@
+//go:build unix
+
+package tui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Suspend stops the process group as Ctrl-Z does in a shell with job
+// control, returning once it is continued.
+func (t *fileTerminal) Suspend() error {
+	return syscall.Kill(0, syscall.SIGSTOP)
+}
+
+// watchSignals handles signals that would leave the terminal in a bad state
+// until the returned function is called.
+func watchSignals() func() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGTSTP, syscall.SIGCONT)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-sigs:
+				handleSignal(sig.(syscall.Signal), sigs)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// handleSignal restores the terminal for sig, then lets it take effect.
+func handleSignal(sig syscall.Signal, sigs chan os.Signal) {
+	switch sig {
+	case syscall.SIGTSTP:
+		Restore()
+		syscall.Kill(os.Getpid(), syscall.SIGSTOP)
+	case syscall.SIGCONT:
+		guard.resume()
+	default:
+		// Prompts end either way. Raising the signal again when the
+		// application also handles it would deliver it twice
+		if !guard.interrupt() {
+			signal.Stop(sigs)
+			syscall.Kill(os.Getpid(), sig)
+		}
+	}
+}
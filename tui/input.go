@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// escapeDelay is how long an ESC at the end of the input waits for the
+// rest of an escape sequence before it is taken as the Escape key. Terminals
+// write whole sequences at once, but a read can still end within one.
+const escapeDelay = 40 * time.Millisecond
+
+// wholeKeyReader is implemented by terminals whose reads always end on a
+// whole key, as scripted terminals' do, so an ESC ending a read is the
+// Escape key without waiting for more.
+type wholeKeyReader interface {
+	ReadsWholeKeys() bool
+}
+
+// input is the input of a terminal in use. Reads can't be interrupted, so
+// input read after a prompt gave up waiting stays here, undecoded, for the
+// next prompt on the same terminal to decode as keys or lines as it needs.
+type input struct {
+	term  Terminal
+	key   any           // Key in inputs, the input itself if term can't be one
+	ready chan struct{} // Signalled when a read finishes
+	buf   []byte        // Read into by the one read under way
+
+	// Guarded by inputs
+	users   int
+	reading bool    // A read was started and hasn't finished
+	dec     Decoder // Input read and not yet decoded
+	err     error   // Error ending the last read, until it is reported
+}
+
+// inputs holds the input of each terminal in use, keyed by terminalKey.
+var inputs = struct {
+	sync.Mutex
+	m map[any]*input
+}{m: make(map[any]*input)}
+
+// openInput returns the input of t. Input left over on a terminal that
+// can't be a map key isn't kept for the next prompt, which can't find it.
+func openInput(t Terminal) *input {
+	inputs.Lock()
+	defer inputs.Unlock()
+
+	key := terminalKey(t)
+	in, ok := inputs.m[key]
+	if key == nil || !ok {
+		in = &input{
+			term:  t,
+			key:   key,
+			ready: make(chan struct{}, 1),
+			buf:   make([]byte, 4096), // Large enough to take a paste in one read
+		}
+		if key == nil {
+			in.key = in
+		}
+		inputs.m[in.key] = in
+	}
+	in.users++
+	return in
+}
+
+// close gives up a use of the input, forgetting it once nobody is using it
+// and no input is left over.
+func (in *input) close() {
+	inputs.Lock()
+	defer inputs.Unlock()
+
+	in.users--
+	in.releaseLocked()
+}
+
+// releaseLocked forgets the input if nobody is using it, no read is under
+// way and no input is left over that a later prompt could find. inputs
+// must be locked.
+func (in *input) releaseLocked() {
+	if in.users > 0 || in.reading || in.dec.Pending() && in.key != any(in) {
+		return
+	}
+
+	if inputs.m[in.key] == in {
+		delete(inputs.m, in.key)
+	}
+}
+
+// read reads from the terminal once, adding what it reads to the decoder.
+// It runs on a goroutine of its own that ends with the read and never waits
+// on a reader, so input arriving after every prompt has gone is kept for
+// the next one.
+func (in *input) read() {
+	n, err := in.term.Read(in.buf)
+
+	inputs.Lock()
+	in.dec.Feed(in.buf[:n])
+	in.err = err
+	in.reading = false
+	in.releaseLocked()
+	inputs.Unlock()
+
+	select {
+	case in.ready <- struct{}{}:
+	default: // A signal is already waiting
+	}
+}
+
+// poll decodes the next event with decode, which reports whether there was
+// one. Without one, the error ending the last read is returned after a
+// final attempt at decoding, or else a read is started if none is under
+// way and the channel signalled when it finishes is returned.
+func (in *input) poll(decode func(dec *Decoder, final bool) bool) (<-chan struct{}, error) {
+	inputs.Lock()
+	defer inputs.Unlock()
+
+	if decode(&in.dec, false) {
+		return nil, nil
+	}
+
+	if err := in.err; err != nil {
+		if decode(&in.dec, true) {
+			return nil, nil
+		}
+		in.err = nil
+		return nil, err
+	}
+
+	if !in.reading {
+		in.reading = true
+		go in.read()
+	}
+	return in.ready, nil
+}
+
+// flush decodes the next event with decode as if no more input will come.
+func (in *input) flush(decode func(dec *Decoder, final bool) bool) bool {
+	inputs.Lock()
+	defer inputs.Unlock()
+
+	return decode(&in.dec, true)
+}
+
+// loneEscape reports whether all that is left of the input is an ESC byte.
+func (in *input) loneEscape() bool {
+	inputs.Lock()
+	defer inputs.Unlock()
+
+	return isLoneEscape(in.dec.buf)
+}
+
+// KeyReader reads key events from a terminal, holding it in raw mode from
+// the moment it is created until it is closed so keystrokes are never
+// echoed between reads. A prompt creates one for as long as it is active.
+type KeyReader struct {
+	term        Terminal
+	gt          *guarded
+	in          *input
+	resized     <-chan struct{}
+	stopResize  func()
+	resumed     <-chan struct{}
+	interrupted <-chan struct{}
+	wholeKeys   bool        // Reads end on whole keys
+	held        *keys.Event // Input read alongside a resize, returned after it
+}
+
+// NewKeyReader puts t into raw mode and starts reading key events from it.
+// Terminals that can't enter raw mode are read as they are.
+func NewKeyReader(t Terminal) *KeyReader {
+	gt := guard.enter(t)
+	r := &KeyReader{term: t, gt: gt, resumed: gt.resumed, interrupted: gt.interrupted}
+	if w, ok := t.(wholeKeyReader); ok {
+		r.wholeKeys = w.ReadsWholeKeys()
+	}
+	if restore, err := t.MakeRaw(); err == nil {
+		guard.setRaw(gt, restore)
+	}
+
+	r.in = openInput(t)
+	r.resized, r.stopResize = watchResize(t)
+	return r
+}
+
+// ReadKey returns the next key event, waiting until one is typed or ctx is
+// done, in which case the error wraps ctx.Err(). Pasted text is returned
+// one key at a time. Besides keys, the terminal being resized is reported
+// as keys.Resize and the process being continued after a suspend as
+// keys.Resume, on which the prompt should draw itself again. Once input
+// ends, ErrEndOfInput is returned.
+func (r *KeyReader) ReadKey(ctx context.Context) (keys.Event, error) {
+	if r.held != nil {
+		ev := *r.held
+		r.held = nil
+		return r.suspendOnCtrlZ(ev), nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return keys.Event{}, canceled(err)
+	}
+
+	var ev keys.Event
+	decode := func(dec *Decoder, final bool) bool {
+		var ok bool
+		ev, ok = dec.Next(final || r.wholeKeys && isLoneEscape(dec.buf))
+		return ok
+	}
+
+	var escape <-chan time.Time
+	for {
+		ready, err := r.in.poll(decode)
+		switch {
+		case errors.Is(err, io.EOF):
+			return keys.Event{}, ErrEndOfInput
+		case err != nil:
+			return keys.Event{}, err
+		}
+
+		if ready != nil {
+			if escape == nil && r.in.loneEscape() {
+				escape = time.After(escapeDelay)
+			}
+
+			select {
+			case <-ready:
+				continue
+			case <-escape:
+				if !r.in.flush(decode) {
+					continue
+				}
+			case <-r.resized:
+				return keys.Named(keys.Resize), nil
+			case <-r.resumed:
+				guard.reenterRaw(r.gt)
+				return keys.Named(keys.Resume), nil
+			case <-r.interrupted:
+				return keys.Event{}, ErrInterrupted
+			case <-ctx.Done():
+				return keys.Event{}, canceled(ctx.Err())
+			}
+		}
+
+		// A resize reported alongside the input is handled first, so the
+		// input is acted on with the new layout
+		select {
+		case <-r.resized:
+			r.held = &ev
+			return keys.Named(keys.Resize), nil
+		default:
+		}
+
+		return r.suspendOnCtrlZ(ev), nil
+	}
+}
+
+// Close restores the terminal from raw mode and stops reading. Input read
+// ahead is kept for the next KeyReader on the same terminal.
+func (r *KeyReader) Close() error {
+	r.stopResize()
+	r.in.close()
+	guard.leaveRaw(r.gt)
+	guard.leave(r.gt)
+	return nil
+}
+
+// ReadKey reads the next key event from the terminal, putting it in raw
+// mode for the duration of the read. Prompts reading more than one key
+// should use a KeyReader, which keeps the terminal in raw mode throughout.
+func ReadKey(t Terminal) (keys.Event, error) {
+	return ReadKeyContext(context.Background(), t)
+}
+
+// ReadKeyContext is like ReadKey but returns early once ctx is done. The
+// terminal is restored before returning and the error wraps ctx.Err().
+func ReadKeyContext(ctx context.Context, t Terminal) (keys.Event, error) {
+	r := NewKeyReader(t)
+	defer r.Close()
+
+	return r.ReadKey(ctx)
+}
+
+// suspendOnCtrlZ suspends the process when ev is Ctrl-Z and the terminal
+// supports it, returning keys.Resume once it continues. Other events are
+// returned as is.
+func (r *KeyReader) suspendOnCtrlZ(ev keys.Event) keys.Event {
+	if s, ok := r.term.(suspender); ok && ev == keys.Ctrl('z') {
+		return guard.suspend(r.gt, s)
+	}
+	return ev
+}
+
+// ReadLine reads the next line of input from the terminal without its line
+// ending. Input is not put in raw mode. A final line without a line ending
+// is returned at the end of input, after which ErrEndOfInput is returned.
+func ReadLine(ctx context.Context, t Terminal) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", canceled(err)
+	}
+
+	in := openInput(t)
+	defer in.close()
+	interrupted := guard.interruption(t)
+
+	var line string
+	decode := func(dec *Decoder, final bool) bool {
+		var ok bool
+		line, ok = dec.Line(final)
+		return ok
+	}
+
+	for {
+		ready, err := in.poll(decode)
+		switch {
+		case errors.Is(err, io.EOF):
+			return "", ErrEndOfInput
+		case err != nil:
+			return "", err
+		case ready == nil:
+			return line, nil
+		}
+
+		select {
+		case <-ready:
+		case <-interrupted:
+			return "", ErrInterrupted
+		case <-ctx.Done():
+			return "", canceled(ctx.Err())
+		}
+	}
+}
+
+// isLoneEscape reports whether b holds only an ESC byte.
+func isLoneEscape(b []byte) bool {
+	return len(b) == 1 && b[0] == keys.KeyEscape
+}
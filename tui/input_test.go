@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+func TestKeyReaderStaysRaw(t *testing.T) {
+	term, _ := newGuardTerminal("ab\x1b[A")
+	reader := NewKeyReader(term)
+
+	want := []keys.Event{keys.Char('a'), keys.Char('b'), keys.Named(keys.Up)}
+	for _, w := range want {
+		ev, err := reader.ReadKey(context.Background())
+		if err != nil {
+			t.Fatalf("ReadKey() error = %v", err)
+		}
+		if ev != w {
+			t.Errorf("ReadKey() = %v; want %v", ev, w)
+		}
+		if !term.raw {
+			t.Error("Terminal left raw mode between reads")
+		}
+	}
+
+	if _, err := reader.ReadKey(context.Background()); !errors.Is(err, ErrEndOfInput) {
+		t.Errorf("ReadKey() at end of input error = %v; want %v", err, ErrEndOfInput)
+	}
+
+	reader.Close()
+
+	if term.raws != 1 {
+		t.Errorf("MakeRaw() called %d times; want 1", term.raws)
+	}
+	if term.raw {
+		t.Error("Close() left the terminal in raw mode")
+	}
+}
+
+func TestKeyReaderTrailingEscape(t *testing.T) {
+	term, _ := newGuardTerminal("a\x1b")
+	reader := NewKeyReader(term)
+	defer reader.Close()
+
+	for _, w := range []keys.Event{keys.Char('a'), keys.Named(keys.Escape)} {
+		if ev, err := reader.ReadKey(context.Background()); err != nil || ev != w {
+			t.Errorf("ReadKey() = %v, %v; want %v", ev, err, w)
+		}
+	}
+}
+
+func TestKeyReaderEscapeAcrossReads(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []keys.Event
+	}{
+		{"sequence completed by next read", []string{"\x1b", "[A"}, []keys.Event{keys.Named(keys.Up)}},
+		{"escape then keys after a pause", []string{"\x1b", "", "[A"},
+			[]keys.Event{keys.Named(keys.Escape), keys.Char('['), keys.Char('A')}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := &guardTerminal{streamTerminal: streamTerminal{&chunkReader{chunks: tt.chunks}, io.Discard}}
+			reader := NewKeyReader(term)
+			defer reader.Close()
+
+			for _, w := range tt.want {
+				if ev, err := reader.ReadKey(context.Background()); err != nil || ev != w {
+					t.Errorf("ReadKey() = %v, %v; want %v", ev, err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestInputLeftOverAcrossModes(t *testing.T) {
+	term, _ := newGuardTerminal("xhello\nyz")
+
+	reader := NewKeyReader(term)
+	if ev, err := reader.ReadKey(context.Background()); err != nil || ev != keys.Char('x') {
+		t.Fatalf("ReadKey() = %v, %v; want %v", ev, err, keys.Char('x'))
+	}
+	reader.Close()
+
+	// Input read along with the key is kept for a line
+	if line, err := ReadLine(context.Background(), term); err != nil || line != "hello" {
+		t.Fatalf("ReadLine() = %q, %v; want %q", line, err, "hello")
+	}
+
+	// And input read along with the line is kept for keys
+	reader = NewKeyReader(term)
+	defer reader.Close()
+	for _, w := range []keys.Event{keys.Char('y'), keys.Char('z')} {
+		if ev, err := reader.ReadKey(context.Background()); err != nil || ev != w {
+			t.Errorf("ReadKey() = %v, %v; want %v", ev, err, w)
+		}
+	}
+}
+
+func TestKeyReaderKeepsLeftoverInput(t *testing.T) {
+	term, _ := newGuardTerminal("xy")
+
+	reader := NewKeyReader(term)
+	if ev, err := reader.ReadKey(context.Background()); err != nil || ev != keys.Char('x') {
+		t.Fatalf("ReadKey() = %v, %v; want %v", ev, err, keys.Char('x'))
+	}
+	reader.Close()
+
+	// The rest of the read is handed to the next reader
+	reader = NewKeyReader(term)
+	if ev, err := reader.ReadKey(context.Background()); err != nil || ev != keys.Char('y') {
+		t.Fatalf("ReadKey() = %v, %v; want %v", ev, err, keys.Char('y'))
+	}
+	reader.Close()
+
+	// The input is forgotten once its last read has finished
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		inputs.Lock()
+		_, running := inputs.m[term]
+		inputs.Unlock()
+
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Input still kept once all of it was used")
+		}
+	}
+}
+
+func TestReadLineReleasesInput(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for range 50 {
+		term, _ := newGuardTerminal("answer\r\n")
+		if line, err := ReadLine(context.Background(), term); err != nil || line != "answer" {
+			t.Fatalf("ReadLine() = %q, %v; want %q", line, err, "answer")
+		}
+
+		inputs.Lock()
+		_, kept := inputs.m[term]
+		inputs.Unlock()
+		if kept {
+			t.Fatal("Input kept after its last line was read")
+		}
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Goroutines = %d after reading lines; want at most %d", after, before)
+	}
+}
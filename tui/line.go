@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lineBuffer holds a line of text being edited as grapheme clusters, along
+// with the position of the editing cursor between clusters.
+type lineBuffer struct {
+	clusters []string
+	cursor   int
+}
+
+// newLineBuffer creates a lineBuffer holding s with the cursor at the end.
+func newLineBuffer(s string) *lineBuffer {
+	clusters := Graphemes(s)
+	return &lineBuffer{clusters: clusters, cursor: len(clusters)}
+}
+
+// String returns the full text of the line.
+func (l *lineBuffer) String() string {
+	return strings.Join(l.clusters, "")
+}
+
+// Before returns the text to the left of the cursor.
+func (l *lineBuffer) Before() string {
+	return strings.Join(l.clusters[:l.cursor], "")
+}
+
+// Insert inserts r at the cursor. Runes that combine with the preceding
+// character, such as accents, join the cluster to the left of the cursor.
+func (l *lineBuffer) Insert(r rune) {
+	before := Graphemes(l.Before() + string(r))
+	after := l.clusters[l.cursor:]
+
+	l.clusters = append(before, after...)
+	l.cursor = len(before)
+}
+
+// Backspace deletes the cluster to the left of the cursor.
+func (l *lineBuffer) Backspace() {
+	if l.cursor > 0 {
+		l.delete(l.cursor-1, l.cursor)
+	}
+}
+
+// DeleteForward deletes the cluster under the cursor.
+func (l *lineBuffer) DeleteForward() {
+	if l.cursor < len(l.clusters) {
+		l.delete(l.cursor, l.cursor+1)
+	}
+}
+
+// Left moves the cursor one cluster to the left.
+func (l *lineBuffer) Left() {
+	if l.cursor > 0 {
+		l.cursor--
+	}
+}
+
+// Right moves the cursor one cluster to the right.
+func (l *lineBuffer) Right() {
+	if l.cursor < len(l.clusters) {
+		l.cursor++
+	}
+}
+
+// Home moves the cursor to the start of the line.
+func (l *lineBuffer) Home() {
+	l.cursor = 0
+}
+
+// End moves the cursor to the end of the line.
+func (l *lineBuffer) End() {
+	l.cursor = len(l.clusters)
+}
+
+// WordLeft moves the cursor to the start of the current or previous word.
+func (l *lineBuffer) WordLeft() {
+	l.cursor = l.scanLeft(l.cursor, isWordCluster)
+}
+
+// WordRight moves the cursor to the end of the current or next word.
+func (l *lineBuffer) WordRight() {
+	pos := l.cursor
+	for pos < len(l.clusters) && !isWordCluster(l.clusters[pos]) {
+		pos++
+	}
+	for pos < len(l.clusters) && isWordCluster(l.clusters[pos]) {
+		pos++
+	}
+	l.cursor = pos
+}
+
+// KillWordBackward deletes the whitespace-delimited word to the left of the cursor.
+func (l *lineBuffer) KillWordBackward() {
+	l.delete(l.scanLeft(l.cursor, isNonSpaceCluster), l.cursor)
+}
+
+// KillAltWordBackward deletes back to the start of the current or previous
+// alphanumeric word.
+func (l *lineBuffer) KillAltWordBackward() {
+	l.delete(l.scanLeft(l.cursor, isWordCluster), l.cursor)
+}
+
+// KillToStart deletes everything to the left of the cursor.
+func (l *lineBuffer) KillToStart() {
+	l.delete(0, l.cursor)
+}
+
+// KillToEnd deletes everything from the cursor to the end of the line.
+func (l *lineBuffer) KillToEnd() {
+	l.delete(l.cursor, len(l.clusters))
+}
+
+// scanLeft skips clusters left of pos that are not in a word, then the word
+// itself, returning the position of the start of that word.
+func (l *lineBuffer) scanLeft(pos int, inWord func(string) bool) int {
+	for pos > 0 && !inWord(l.clusters[pos-1]) {
+		pos--
+	}
+	for pos > 0 && inWord(l.clusters[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// delete removes the clusters in [from, to) and places the cursor at from.
+func (l *lineBuffer) delete(from, to int) {
+	if from >= to {
+		return
+	}
+	l.clusters = append(l.clusters[:from], l.clusters[to:]...)
+	l.cursor = from
+}
+
+// isWordCluster reports whether a cluster is part of an alphanumeric word.
+func isWordCluster(c string) bool {
+	r, _ := utf8.DecodeRuneInString(c)
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isNonSpaceCluster reports whether a cluster is anything but whitespace.
+func isNonSpaceCluster(c string) bool {
+	r, _ := utf8.DecodeRuneInString(c)
+	return !unicode.IsSpace(r)
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+func TestLineBufferBackspace(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"a", ""},
+		{"abc", "ab"},
+		{"café", "caf"},
+		{"café", "caf"},
+		{"日本", "日"},
+		{"hi👩‍💻", "hi"},
+		{"🇯🇵🇫🇷", "🇯🇵"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			line := newLineBuffer(tt.s)
+			line.Backspace()
+			if got := line.String(); got != tt.want {
+				t.Errorf("Backspace() on %q = %q; want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineBufferInsert(t *testing.T) {
+	line := newLineBuffer("ac")
+	line.Left()
+	line.Insert('b')
+
+	if got := line.String(); got != "abc" {
+		t.Errorf("Insert() in the middle = %q; want %q", got, "abc")
+	}
+	if got := line.Before(); got != "ab" {
+		t.Errorf("Before() after insert = %q; want %q", got, "ab")
+	}
+
+	// A combining accent joins the character to the left of the cursor
+	line.Insert('́')
+	if got := line.Before(); got != "ab́" {
+		t.Errorf("Before() after combining insert = %q; want %q", got, "ab́")
+	}
+	if len(line.clusters) != 3 {
+		t.Errorf("Line has %d clusters; want 3", len(line.clusters))
+	}
+}
+
+func TestLineBufferMovement(t *testing.T) {
+	line := newLineBuffer("日本語")
+
+	line.Left()
+	if got := line.Before(); got != "日本" {
+		t.Errorf("Left() Before() = %q; want %q", got, "日本")
+	}
+
+	line.Home()
+	line.Left()
+	if line.cursor != 0 {
+		t.Errorf("Left() at start moved cursor to %d", line.cursor)
+	}
+
+	line.Right()
+	if got := line.Before(); got != "日" {
+		t.Errorf("Right() Before() = %q; want %q", got, "日")
+	}
+
+	line.End()
+	line.Right()
+	if line.cursor != 3 {
+		t.Errorf("Right() at end moved cursor to %d", line.cursor)
+	}
+}
+
+func TestLineBufferWords(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		op         func(*lineBuffer)
+		wantText   string
+		wantBefore string
+	}{
+		{"word left from end", "foo bar-baz", 11, (*lineBuffer).WordLeft, "foo bar-baz", "foo bar-"},
+		{"word left skips spaces", "foo bar  ", 9, (*lineBuffer).WordLeft, "foo bar  ", "foo "},
+		{"word right", "foo bar-baz", 0, (*lineBuffer).WordRight, "foo bar-baz", "foo"},
+		{"word right skips separators", "foo bar-baz", 7, (*lineBuffer).WordRight, "foo bar-baz", "foo bar-baz"},
+		{"kill word backward", "git commit -m", 13, (*lineBuffer).KillWordBackward, "git commit ", "git commit "},
+		{"kill word backward over spaces", "foo bar  ", 9, (*lineBuffer).KillWordBackward, "foo ", "foo "},
+		{"kill alt word backward", "foo bar-baz", 11, (*lineBuffer).KillAltWordBackward, "foo bar-", "foo bar-"},
+		{"kill to start", "hello world", 6, (*lineBuffer).KillToStart, "world", ""},
+		{"kill to end", "hello world", 5, (*lineBuffer).KillToEnd, "hello", "hello"},
+		{"delete forward", "abc", 1, (*lineBuffer).DeleteForward, "ac", "a"},
+		{"delete forward at end", "abc", 3, (*lineBuffer).DeleteForward, "abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := newLineBuffer(tt.text)
+			line.cursor = tt.cursor
+			tt.op(line)
+
+			if got := line.String(); got != tt.wantText {
+				t.Errorf("String() = %q; want %q", got, tt.wantText)
+			}
+			if got := line.Before(); got != tt.wantBefore {
+				t.Errorf("Before() = %q; want %q", got, tt.wantBefore)
+			}
+		})
+	}
+}
+
+func TestEditLineBindings(t *testing.T) {
+	tests := []struct {
+		name       string
+		ev         keys.Event
+		wantBefore string
+		wantText   string
+	}{
+		{"left arrow", keys.Named(keys.Left), "hello worl", "hello world"},
+		{"ctrl+b", keys.Ctrl('b'), "hello worl", "hello world"},
+		{"home", keys.Named(keys.Home), "", "hello world"},
+		{"ctrl+a", keys.Ctrl('a'), "", "hello world"},
+		{"alt+b", keys.Alt('b'), "hello ", "hello world"},
+		{"ctrl+left", keys.Named(keys.Left, keys.ModCtrl), "hello ", "hello world"},
+		{"ctrl+w", keys.Ctrl('w'), "hello ", "hello "},
+		{"ctrl+u", keys.Ctrl('u'), "", ""},
+		{"alt+backspace", keys.Named(keys.Backspace, keys.ModAlt), "hello ", "hello "},
+		{"backspace", keys.Named(keys.Backspace), "hello worl", "hello worl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := newLineBuffer("hello world")
+			if !editLine(line, tt.ev) {
+				t.Fatalf("editLine(%v) was not handled", tt.ev)
+			}
+			if got := line.Before(); got != tt.wantBefore {
+				t.Errorf("Before() = %q; want %q", got, tt.wantBefore)
+			}
+			if got := line.String(); got != tt.wantText {
+				t.Errorf("String() = %q; want %q", got, tt.wantText)
+			}
+		})
+	}
+
+	if editLine(newLineBuffer("x"), keys.Named(keys.F1)) {
+		t.Error("editLine() handled a non-editing key")
+	}
+}
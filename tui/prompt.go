@@ -1,80 +1,97 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/engmtcdrm/go-ansi"
 	"github.com/engmtcdrm/go-pardon/keys"
-	"golang.org/x/term"
 )
 
 var (
 	// ErrUserAborted is returned when the user cancels a prompt operation.
 	ErrUserAborted = errors.New("user aborted")
-)
-
-var (
-	// navigationKeys defines a map of byte keycodes for navigation actions.
-	// These keys are used for cursor movement and selection in interactive prompts.
-	navigationKeys = map[byte]bool{
-		keys.KeyUp:    true,
-		keys.KeyDown:  true,
-		keys.KeyLeft:  true,
-		keys.KeyRight: true,
-	}
 
-	// lastInputWasEscSeq tracks whether the previous input was part of an escape sequence.
-	// This helps with proper handling of multi-byte terminal input sequences.
-	lastInputWasEscSeq = false
+	// ErrEndOfInput is returned when input ends before a prompt is
+	// answered.
+	ErrEndOfInput = errors.New("input ended before the prompt was answered")
 
-	// inputBuffer provides buffering for handling paste operations and multi-byte input.
-	inputBuffer []byte
+	// ErrInterrupted is returned when an interrupt, termination or hangup
+	// signal ends a prompt.
+	ErrInterrupted = errors.New("interrupted by signal")
 )
 
 // InputPrompt provides a generic framework for text-based input prompts.
 type InputPrompt[T any] struct {
-	appendInputFn  func(T, byte) T
+	term           Terminal
+	toStringFn     func(T) string
+	fromStringFn   func(string) T
 	displayInputFn func(T) string
-	removeLastFn   func(T) T
 	answerFn       func(string) string
 	validateFn     func(T) error
+	acceptFn       func(rune) bool
+	keyFn          func(keys.Event, string) (string, bool)
+	hintFn         func(string) []string
+	masked         bool // Input is hidden, so it must never be echoed
+	accessible     bool
+	multiline      bool // Enter starts a new line and Ctrl-D submits
+	height         int  // Lines of multi-line input shown at once
+	maxLines       int
+	charLimit      int
 }
 
 // NewStringPrompt creates an InputPrompt for plaintext string input.
 func NewStringPrompt() *InputPrompt[string] {
 	return &InputPrompt[string]{
-		appendInputFn:  func(s string, b byte) string { return s + string(b) },
+		term:           DefaultTerminal(),
+		toStringFn:     func(s string) string { return s },
+		fromStringFn:   func(s string) string { return s },
 		displayInputFn: func(s string) string { return s },
-		removeLastFn: func(s string) string {
-			if len(s) > 0 {
-				return s[:len(s)-1]
-			}
-			return s
-		},
-		answerFn:   func(s string) string { return s },
-		validateFn: func(s string) error { return nil },
+		answerFn:       func(s string) string { return s },
+		validateFn:     func(s string) error { return nil },
 	}
 }
 
 // NewPasswordPrompt creates an InputPrompt for secure password input with masking.
 func NewPasswordPrompt() *InputPrompt[[]byte] {
 	return &InputPrompt[[]byte]{
-		appendInputFn:  func(b []byte, c byte) []byte { return append(b, c) },
+		term:           DefaultTerminal(),
+		toStringFn:     func(b []byte) string { return string(b) },
+		fromStringFn:   func(s string) []byte { return []byte(s) },
 		displayInputFn: func(b []byte) string { return "" }, // Mask all input
-		removeLastFn: func(b []byte) []byte {
-			if len(b) > 0 {
-				return b[:len(b)-1]
-			}
-			return b
-		},
-		answerFn:   func(s string) string { return s },
-		validateFn: func(b []byte) error { return nil },
+		answerFn:       func(s string) string { return s },
+		validateFn:     func(b []byte) error { return nil },
+		masked:         true,
 	}
 }
 
+// NewTextPrompt creates an InputPrompt for plaintext input of several
+// lines. Enter starts a new line, and Ctrl-D or Alt-Enter submits the text.
+func NewTextPrompt() *InputPrompt[string] {
+	p := NewStringPrompt()
+	p.multiline = true
+	p.height = 5
+	return p
+}
+
+// Terminal sets the terminal the prompt reads from and renders to.
+func (p *InputPrompt[T]) Terminal(t Terminal) *InputPrompt[T] {
+	if t != nil {
+		p.term = t
+	}
+	return p
+}
+
+// Accessible turns accessible mode on or off. In accessible mode the prompt
+// reads a line at a time without redrawing in place, even on an interactive
+// terminal, and validation errors are written as plain text.
+func (p *InputPrompt[T]) Accessible(on bool) *InputPrompt[T] {
+	p.accessible = on
+	return p
+}
+
 // Validate sets a validation function for the input prompt.
 func (p *InputPrompt[T]) Validate(fn func(T) error) *InputPrompt[T] {
 	if fn != nil {
@@ -99,230 +116,282 @@ func (p *InputPrompt[T]) AnswerFunc(fn func(string) string) *InputPrompt[T] {
 	return p
 }
 
-// getActualInputLength gets the actual length of input for display calculations
-// This is used for incremental updates where display length != actual length
-func getActualInputLength[T any](input T) int {
-	switch v := any(input).(type) {
-	case []byte:
-		return len(v)
-	case string:
-		return len(v)
-	default:
-		return 0
+// Accept sets a function choosing which typed characters are inserted.
+// Characters it rejects are ignored.
+func (p *InputPrompt[T]) Accept(fn func(rune) bool) *InputPrompt[T] {
+	p.acceptFn = fn
+	return p
+}
+
+// KeyFunc sets a function handling keys that don't edit the line, such as
+// Up and Down to step a number. It is given the current input and returns
+// the input replacing it, or false to ignore the key.
+func (p *InputPrompt[T]) KeyFunc(fn func(ev keys.Event, input string) (string, bool)) *InputPrompt[T] {
+	p.keyFn = fn
+	return p
+}
+
+// Height sets how many lines of multi-line input are shown at once,
+// scrolling to keep the cursor in view.
+func (p *InputPrompt[T]) Height(n int) *InputPrompt[T] {
+	if n > 0 {
+		p.height = n
 	}
+	return p
+}
+
+// MaxLines sets the most lines multi-line input can have. Zero means no
+// limit.
+func (p *InputPrompt[T]) MaxLines(n int) *InputPrompt[T] {
+	p.maxLines = max(n, 0)
+	return p
+}
+
+// CharLimit sets the most characters the input can have, counting each
+// line break as one. Zero means no limit.
+func (p *InputPrompt[T]) CharLimit(n int) *InputPrompt[T] {
+	p.charLimit = max(n, 0)
+	return p
+}
+
+// Hint sets a function returning lines shown below the input, such as
+// completions of it. It is called each time the input is drawn.
+func (p *InputPrompt[T]) Hint(fn func(input string) []string) *InputPrompt[T] {
+	p.hintFn = fn
+	return p
 }
 
-// Display displays the input prompt and handles user input
+// Check runs the validation function against value, after checking it is
+// within the line and character limits.
+func (p *InputPrompt[T]) Check(value T) error {
+	text := p.toStringFn(value)
+	if p.maxLines > 0 && strings.Count(text, "\n")+1 > p.maxLines {
+		return fmt.Errorf("enter no more than %d lines", p.maxLines)
+	}
+	if p.charLimit > 0 && len(Graphemes(text)) > p.charLimit {
+		return fmt.Errorf("enter no more than %d characters", p.charLimit)
+	}
+	return p.validateFn(value)
+}
+
+// Display displays the input prompt and handles user input.
+// The line can be edited with readline-style keys: arrows, Home/End,
+// Ctrl-A/E/B/F, Alt-B/F word jumps, Ctrl-W/U/K kills and Delete.
 func (p *InputPrompt[T]) Display(prompt string, value *T) error {
-	input := *value
+	return p.DisplayContext(context.Background(), prompt, value)
+}
+
+// DisplayContext is like Display but gives up when ctx is done, erasing the
+// prompt and returning the context's error.
+func (p *InputPrompt[T]) DisplayContext(ctx context.Context, prompt string, value *T) error {
+	if p.multiline {
+		return p.displayText(ctx, prompt, value)
+	}
+
+	if !IsInteractive(p.term) || p.accessible {
+		return p.displayLines(ctx, prompt, value)
+	}
+
+	line := newLineBuffer(p.toStringFn(*value))
 	var lastError string
 	showError := false
-	var lastInputLen int
-	var wasShowingError bool
-
-	fmt.Print(prompt)
 
+	screen := NewRenderer(p.term)
 	redraw := func() {
-		currentInput := p.displayInputFn(input)
-		currentInputLen := len(currentInput)
-		actualInputLen := getActualInputLength(input)
+		currentInput := p.displayInputFn(p.fromStringFn(line.String()))
+		inputBefore := p.displayInputFn(p.fromStringFn(line.Before()))
 
+		lines := []string{prompt + currentInput}
+		if p.hintFn != nil {
+			lines = append(lines, p.hintFn(line.String())...)
+		}
 		if showError && lastError != "" {
-			displayErr := fmt.Sprintf(
-				"\r%s%s%s\n\r%s%s* %s%s%s\r%s%s",
-				ansi.ClearLine,
-				prompt,
-				currentInput,
-				ansi.ClearLine,
-				ansi.Red,
-				lastError,
-				ansi.Reset,
-				ansi.CursorUp(1),
-				prompt,
-				currentInput,
-			)
-			fmt.Print(displayErr)
-			wasShowingError = true
-		} else {
-			// If we were showing an error and now we're not, clear the error line
-			if wasShowingError {
-				clearErr := fmt.Sprintf(
-					"\r%s%s%s\n\r%s%s\r%s%s",
-					ansi.ClearLine,
-					prompt,
-					currentInput,
-					ansi.ClearLine,
-					ansi.CursorUp(1),
-					prompt,
-					currentInput,
-				)
-				fmt.Print(clearErr)
-				wasShowingError = false
-			} else {
-				// No error - use incremental update approach
-				if currentInputLen == 0 && actualInputLen > 0 {
-					// This is a password field - no visual updates needed
-					// The cursor stays in place for security
-				} else if currentInputLen < lastInputLen {
-					// Input got shorter (backspace)
-					extraChars := lastInputLen - currentInputLen
-					backspaceSeq := fmt.Sprintf("%s%s%s",
-						strings.Repeat("\b", extraChars),
-						strings.Repeat(" ", extraChars),
-						strings.Repeat("\b", extraChars))
-					fmt.Print(backspaceSeq)
-				} else if currentInputLen > lastInputLen {
-					// Input got longer - just append the new characters
-					newChars := currentInput[lastInputLen:]
-					fmt.Print(newChars)
-				}
-				// For same length or other cases, we don't need to do anything
-				// since the cursor is already in the right position
-			}
+			lines = append(lines, FormatError(lastError))
 		}
 
-		lastInputLen = currentInputLen
+		// Masked input displays nothing, so the cursor stays in place for security
+		screen.Render(lines, 0, StringWidth(prompt+inputBefore))
 	}
 
-	// Initial display of any existing input
-	if len(p.displayInputFn(input)) > 0 {
-		fmt.Print(p.displayInputFn(input))
-		lastInputLen = len(p.displayInputFn(input))
-	}
+	defer Guard(p.term)()
+	reader := NewKeyReader(p.term)
+	defer reader.Close()
+
+	redraw()
 
 	for {
-		keyCode := GetInput()
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				screen.Erase()
+			}
+			return err
+		}
 
-		switch keyCode {
-		case keys.KeyEnter, keys.KeyCarriageReturn:
-			if err := p.validateFn(input); err != nil {
+		switch {
+		case ev.Key == keys.Enter:
+			input := p.fromStringFn(line.String())
+			if err := p.Check(input); err != nil {
 				lastError = err.Error()
 				showError = true
 				redraw()
 				continue
 			}
 			*value = input
-			// Clear any error lines and display final output
-			var finalOutput string
-
-			if showError {
-				finalOutput = fmt.Sprintf(
-					"\r%s\n%s\r%s%s\n",
-					ansi.ClearLine,
-					ansi.ClearLine,
-					prompt,
-					p.answerFn(p.displayInputFn(input)),
-				)
-			} else {
-				finalOutput = fmt.Sprintf(
-					"\r%s%s%s\n",
-					ansi.ClearLine,
-					prompt,
-					p.answerFn(p.displayInputFn(input)),
-				)
-			}
-			fmt.Print(finalOutput)
+			screen.Finish(prompt + p.answerFn(p.displayInputFn(input)))
 			return nil
-		case keys.KeyCtrlC:
-			// Clear any error lines
-			var finalOutput string
-
-			if showError {
-				finalOutput = fmt.Sprintf("\r%s\n%s\r", ansi.ClearLine, ansi.ClearLine)
-			} else {
-				finalOutput = fmt.Sprintf("\r%s", ansi.ClearLine)
-			}
-			fmt.Print(finalOutput)
+		case ev == keys.Ctrl('c'):
+			screen.Erase()
 			return ErrUserAborted
-		case keys.KeyBackspace:
-			input = p.removeLastFn(input)
-			showError = false
+		case ev.Key == keys.Resize:
+			redraw()
+			continue
+		case ev.Key == keys.Resume:
+			screen.Reset()
 			redraw()
-		case keys.KeyUp, keys.KeyDown, keys.KeyLeft, keys.KeyRight:
-			// Only treat as navigation keys if they came from escape sequences
-			if lastInputWasEscSeq {
-				showError = false
-				// Don't redraw for navigation keys to prevent flicker
+			continue
+		case ev.IsPrintable():
+			if p.acceptFn != nil && !p.acceptFn(ev.Rune) {
 				continue
-			} else {
-				// Treat as regular input (A=65, B=66, C=67, D=68)
-				input = p.appendInputFn(input, keyCode)
-				showError = false
-				redraw()
 			}
-		default:
-			// Filter out control characters (0-31), allow all others
-			if keyCode >= 32 {
-				// Printable ASCII (32-126) and extended characters (128+)
-				input = p.appendInputFn(input, keyCode)
-				showError = false
-				redraw()
+			if p.charLimit > 0 && len(line.clusters) >= p.charLimit {
+				continue
 			}
+			line.Insert(ev.Rune)
+		case editLine(line, ev):
+		case p.keyFn != nil:
+			input, ok := p.keyFn(ev, line.String())
+			if !ok {
+				continue
+			}
+			line = newLineBuffer(input)
+		default:
+			continue
 		}
+
+		showError = false
+		redraw()
 	}
 }
 
-// GetInput reads raw keyboard input from the terminal.
-// Handles buffered input, raw mode, and ANSI escape sequences.
-func GetInput() byte {
-	// If we have buffered input from a paste operation, return it first
-	if len(inputBuffer) > 0 {
-		result := inputBuffer[0]
-		inputBuffer = inputBuffer[1:]
-		lastInputWasEscSeq = false
-		return result
-	}
+// displayLines asks for the input a line at a time, for terminals that are
+// not interactive and for accessible mode. An empty line keeps the current
+// value. Interactive terminals echo the line as it is typed, except for
+// masked input, which is read without echo.
+func (p *InputPrompt[T]) displayLines(ctx context.Context, prompt string, value *T) error {
+	echoed := IsInteractive(p.term)
+
+	for {
+		fmt.Fprint(p.term, prompt)
 
-	// Use stdin file descriptor for cross-platform compatibility
-	fd := int(os.Stdin.Fd())
-
-	// Save the original terminal state
-	oldState, err := term.MakeRaw(fd)
-	if err != nil {
-		// Fallback if raw mode fails - read normally
-		readBytes := make([]byte, 1)
-		_, readErr := os.Stdin.Read(readBytes)
-		if readErr != nil {
-			return 0
+		var text string
+		var err error
+		if echoed && p.masked {
+			text, err = readHidden(ctx, p.term)
+		} else {
+			text, err = ReadLine(ctx, p.term)
+		}
+		if err != nil {
+			fmt.Fprintln(p.term)
+			return err
+		}
+
+		input := p.fromStringFn(text)
+		if text == "" {
+			input = *value
+		}
+
+		if err := p.Check(input); err != nil {
+			if !echoed {
+				fmt.Fprintln(p.term, p.displayInputFn(input))
+			}
+			if p.accessible {
+				fmt.Fprintln(p.term, FormatPlainError(err.Error()))
+			} else {
+				fmt.Fprintln(p.term, FormatError(err.Error()))
+			}
+			continue
 		}
-		return readBytes[0]
-	}
-	defer term.Restore(fd, oldState)
-
-	// Read input - use a larger buffer to handle paste operations
-	readBytes := make([]byte, 4096) // Increased to 4KB to handle larger pastes
-	read, err := os.Stdin.Read(readBytes)
-	if err != nil {
-		// Handle read error, it might be due to signal interruption
-		return 0
-	}
 
-	// If we read more than 3 bytes, it's likely a paste operation
-	if read > 3 {
-		// Buffer all characters except the first one
-		inputBuffer = append(inputBuffer, readBytes[1:read]...)
-		lastInputWasEscSeq = false
-		return readBytes[0]
+		*value = input
+		if !echoed {
+			fmt.Fprintln(p.term, p.answerFn(p.displayInputFn(input)))
+		}
+		return nil
 	}
+}
+
+// readHidden reads a line of input in raw mode so it is not echoed, then
+// moves to the next line as the line ending would be echoed. The line can
+// be edited with the same keys as in an interactive prompt.
+func readHidden(ctx context.Context, t Terminal) (string, error) {
+	defer Guard(t)()
+	reader := NewKeyReader(t)
+	defer reader.Close()
 
-	// Handle escape sequences (arrow keys)
-	if read == 3 && readBytes[0] == keys.KeyEscape && readBytes[1] == keys.KeyLeftBracket {
-		// This is a proper ANSI escape sequence (ESC[X)
-		if _, ok := navigationKeys[readBytes[2]]; ok {
-			lastInputWasEscSeq = true
-			return readBytes[2]
+	line := newLineBuffer("")
+	for {
+		ev, err := reader.ReadKey(ctx)
+		switch {
+		case err != nil:
+			return "", err
+		case ev.Key == keys.Enter:
+			fmt.Fprintln(t)
+			return line.String(), nil
+		case ev == keys.Ctrl('c'):
+			return "", ErrUserAborted
+		case ev.IsPrintable():
+			line.Insert(ev.Rune)
+		default:
+			editLine(line, ev)
 		}
-		// If it's an escape sequence but not a navigation key, ignore it
-		lastInputWasEscSeq = false
-		return 0
 	}
+}
 
-	// For any other input (1, 2, or 3 bytes that aren't escape sequences),
-	// return the first byte which contains the actual character
-	lastInputWasEscSeq = false
-	if read > 0 {
-		return readBytes[0]
+// editLine applies a line editing key to line, returning false if the key
+// is not an editing key.
+func editLine(line *lineBuffer, ev keys.Event) bool {
+	switch {
+	case ev.Key == keys.Backspace && ev.Mod&keys.ModAlt != 0:
+		line.KillAltWordBackward()
+	case ev.Key == keys.Backspace:
+		line.Backspace()
+	case ev.Key == keys.Delete:
+		line.DeleteForward()
+	case ev.Key == keys.Left && ev.Mod&keys.ModCtrl != 0, ev == keys.Alt('b'):
+		line.WordLeft()
+	case ev.Key == keys.Right && ev.Mod&keys.ModCtrl != 0, ev == keys.Alt('f'):
+		line.WordRight()
+	case ev.Key == keys.Left, ev == keys.Ctrl('b'):
+		line.Left()
+	case ev.Key == keys.Right, ev == keys.Ctrl('f'):
+		line.Right()
+	case ev.Key == keys.Home, ev == keys.Ctrl('a'):
+		line.Home()
+	case ev.Key == keys.End, ev == keys.Ctrl('e'):
+		line.End()
+	case ev == keys.Ctrl('w'):
+		line.KillWordBackward()
+	case ev == keys.Ctrl('u'):
+		line.KillToStart()
+	case ev == keys.Ctrl('k'):
+		line.KillToEnd()
+	default:
+		return false
 	}
+	return true
+}
+
+// cursorForward returns the sequence moving the cursor n columns right.
+// Nothing is emitted for zero, as terminals treat a zero count as one.
+func cursorForward(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return ansi.CursorForward(n)
+}
 
-	return 0
+// canceled wraps a context error returned when a prompt is interrupted.
+func canceled(err error) error {
+	return fmt.Errorf("prompt canceled: %w", err)
 }
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package tui
+
+// keepOutputProcessing does nothing where raw mode leaves output alone.
+func keepOutputProcessing(fd int) error {
+	return nil
+}
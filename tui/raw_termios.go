@@ -0,0 +1,18 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package tui
+
+import "golang.org/x/sys/unix"
+
+// keepOutputProcessing turns output processing back on for fd after it was
+// put in raw mode, so line feeds written by prompts still return the cursor
+// to the start of the line.
+func keepOutputProcessing(fd int) error {
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return err
+	}
+
+	termios.Oflag |= unix.OPOST
+	return unix.IoctlSetTermios(fd, ioctlWriteTermios, termios)
+}
@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+)
+
+// Renderer draws a prompt as a frame of lines starting at the line the
+// cursor was on when the prompt began. It remembers the frame on screen and
+// writes only what changed when the next one is drawn, keeping redraws
+// small over slow connections. Lines too long for the terminal wrap onto
+// further rows; when the terminal is resized the frame on screen is erased,
+// assuming the terminal reflowed or scrolled it, and drawn again in full.
+type Renderer struct {
+	term   Terminal
+	drawn  bool     // A frame is on screen
+	lines  []string // Lines of the frame on screen
+	rows   []int    // Screen rows taken by each line
+	width  int      // Terminal width the frame was drawn at
+	screen int      // Terminal height the frame was drawn at
+	row    int      // Row of the cursor, counted from the top of the frame
+	col    int      // Column of the cursor, or -1 if not known
+	height int      // Rows from the top of the frame that exist on screen
+
+	cursorLine, cursorCol int // Where the cursor was left in the frame
+}
+
+// NewRenderer creates a Renderer drawing to t.
+func NewRenderer(t Terminal) *Renderer {
+	return &Renderer{term: t, col: -1}
+}
+
+// Draw draws lines as the new frame, leaving the cursor at the end of the
+// last line. It suits prompts that hide the cursor.
+func (r *Renderer) Draw(lines []string) {
+	last := len(lines) - 1
+	if last < 0 {
+		r.Render(lines, 0, 0)
+		return
+	}
+	r.Render(lines, last, StringWidth(lines[last]))
+}
+
+// Render draws lines as the new frame, leaving the cursor col cells into
+// the line at index line.
+func (r *Renderer) Render(lines []string, line, col int) {
+	var out strings.Builder
+	r.draw(&out, lines)
+
+	width := r.width
+	top := 0
+	for i := 0; i < line && i < len(r.rows); i++ {
+		top += r.rows[i]
+	}
+
+	offset, cell := 0, 0
+	if line < len(lines) {
+		offset, cell = wrapCell(lines[line], col, width)
+	}
+	r.moveToCell(&out, top+offset, min(cell, width-1))
+
+	r.cursorLine, r.cursorCol = line, col
+	fmt.Fprint(r.term, out.String())
+}
+
+// Finish draws lines as the final frame, such as a prompt with its answer,
+// and leaves the cursor at the start of the line below it. The next frame
+// is drawn from there.
+func (r *Renderer) Finish(lines ...string) {
+	var out strings.Builder
+	r.draw(&out, lines)
+	r.moveTo(&out, sum(r.rows))
+
+	r.reset()
+	fmt.Fprint(r.term, out.String())
+}
+
+// Erase removes the frame from the screen, leaving the cursor where it
+// started.
+func (r *Renderer) Erase() {
+	if !r.drawn {
+		return
+	}
+
+	var out strings.Builder
+	r.eraseFrame(&out)
+	fmt.Fprint(r.term, out.String())
+}
+
+// Reset leaves the frame on screen and forgets it, so the next frame is
+// drawn in full on the line below. Call it once something else may have
+// written to the terminal, for example after the process was resumed.
+func (r *Renderer) Reset() {
+	if !r.drawn {
+		return
+	}
+
+	var out strings.Builder
+	r.moveTo(&out, sum(r.rows))
+	r.reset()
+	fmt.Fprint(r.term, out.String())
+}
+
+// Rows returns the number of screen rows the frame on screen takes.
+func (r *Renderer) Rows() int {
+	return sum(r.rows)
+}
+
+// draw writes the changes from the frame on screen to lines. Lines that
+// keep their place are rewritten from the first cell that changed; once a
+// line takes a different number of rows, everything below it moves and is
+// drawn again.
+func (r *Renderer) draw(out *strings.Builder, lines []string) {
+	// Once erased, everything below the cursor is already clear
+	redrawRest := false
+	width, screen := TerminalWidth(r.term), TerminalHeight(r.term)
+	if r.drawn && (width != r.width || screen != r.screen) {
+		r.eraseFrame(out)
+		redrawRest = true
+	}
+
+	rows := make([]int, len(lines))
+	for i, line := range lines {
+		rows[i] = ScreenRows([]string{line}, width)
+	}
+
+	oldRows := sum(r.rows)
+	top := 0
+	for i, line := range lines {
+		kept := r.drawn && !redrawRest && i < len(r.lines) && r.rows[i] == rows[i]
+		if kept && r.lines[i] == line {
+			top += rows[i]
+			continue
+		}
+
+		if kept && rows[i] == 1 {
+			r.rewrite(out, top, r.lines[i], line)
+		} else {
+			r.moveTo(out, top)
+			if !redrawRest {
+				out.WriteString(ansi.ClearFromCursorToEndScreen)
+				redrawRest = true
+			}
+			out.WriteString(line)
+			r.row = top + rows[i] - 1
+			r.col = -1
+			if _, end := wrapCell(line, -1, width); end < width {
+				r.col = end
+			}
+			r.height = max(r.height, r.row+1)
+		}
+		top += rows[i]
+	}
+
+	// Clear rows left over from a taller frame
+	if !redrawRest && top < oldRows {
+		r.moveTo(out, top)
+		out.WriteString(ansi.ClearFromCursorToEndScreen)
+	}
+
+	r.drawn = true
+	r.lines = append(r.lines[:0], lines...)
+	r.rows = rows
+	r.width = width
+	r.screen = screen
+}
+
+// rewrite replaces old with line on row, skipping the cells both have in
+// common.
+func (r *Renderer) rewrite(out *strings.Builder, row int, old, line string) {
+	n := commonPrefix(old, line)
+	r.moveToCell(out, row, StringWidth(line[:n]))
+	out.WriteString(line[n:])
+
+	// A longer line overwrites everything, and a line filling the row
+	// can't be cleared after without losing its last cell
+	width := StringWidth(line)
+	if width < StringWidth(old) {
+		out.WriteString(ansi.ClearToEnd)
+	}
+
+	r.col = -1
+	if width < r.width {
+		r.col = width
+	}
+}
+
+// moveToCell moves the cursor to col on row, unless it is already there.
+func (r *Renderer) moveToCell(out *strings.Builder, row, col int) {
+	if r.row == row && r.col == col {
+		return
+	}
+
+	r.moveTo(out, row)
+	out.WriteString(cursorForward(col))
+	r.col = col
+}
+
+// moveTo moves the cursor to the start of row, counted from the top of the
+// frame. Rows below the frame are added with line feeds, so the screen
+// scrolls if there is no room for them.
+func (r *Renderer) moveTo(out *strings.Builder, row int) {
+	if r.col != 0 {
+		out.WriteString("\r")
+	}
+
+	switch {
+	case row < r.row:
+		out.WriteString(ansi.CursorUp(r.row - row))
+	case row > r.row:
+		down := min(row, r.height-1) - r.row
+		if down > 0 {
+			out.WriteString(ansi.CursorDown(down))
+		}
+		out.WriteString(strings.Repeat("\n", row-r.row-max(down, 0)))
+	}
+
+	r.row, r.col = row, 0
+	r.height = max(r.height, row+1)
+}
+
+// eraseFrame clears the frame from the top down and forgets it. If the
+// width changed, the frame is assumed to have been reflowed to the new
+// width, which moves the cursor to a different row.
+func (r *Renderer) eraseFrame(out *strings.Builder) {
+	width := TerminalWidth(r.term)
+
+	row := r.row
+	if width != r.width {
+		row = 0
+		for i := 0; i < r.cursorLine && i < len(r.lines); i++ {
+			row += ScreenRows([]string{r.lines[i]}, width)
+		}
+		if r.cursorLine < len(r.lines) {
+			cursorRow, _ := wrapCell(r.lines[r.cursorLine], r.cursorCol, width)
+			row += cursorRow
+		}
+	}
+
+	out.WriteString("\r")
+	if row > 0 {
+		out.WriteString(ansi.CursorUp(row))
+	}
+	out.WriteString(ansi.ClearFromCursorToEndScreen)
+
+	r.reset()
+	r.col = 0
+}
+
+// reset forgets the frame on screen.
+func (r *Renderer) reset() {
+	r.drawn = false
+	r.lines = r.lines[:0]
+	r.rows = nil
+	r.row, r.col, r.height = 0, -1, 0
+	r.cursorLine, r.cursorCol = 0, 0
+}
+
+// commonPrefix returns the length in bytes of the longest run of whole
+// grapheme clusters a and b start with. It stops at escape sequences, as
+// skipping one would lose the style it sets.
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] != '\x1b' {
+		size := nextGrapheme(a[n:])
+		if size != nextGrapheme(b[n:]) || a[n:n+size] != b[n:n+size] {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// sum returns the total of ns.
+func sum(ns []int) int {
+	total := 0
+	for _, n := range ns {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/engmtcdrm/go-ansi"
+)
+
+// sizedTerminal is an in-memory terminal of a fixed size.
+type sizedTerminal struct {
+	streamTerminal
+	width, height int
+}
+
+func newSizedTerminal(width, height int) (*sizedTerminal, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &sizedTerminal{streamTerminal{strings.NewReader(""), &out}, width, height}, &out
+}
+
+func (t *sizedTerminal) Size() (int, int, error) {
+	return t.width, t.height, nil
+}
+
+func TestRendererDraw(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  []string
+		second []string
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			first:  []string{"Pick:", "> A", "  B"},
+			second: []string{"Pick:", "> A", "  B"},
+			want:   "",
+		},
+		{
+			name:   "changed lines only",
+			first:  []string{"Pick:", "> A", "  B"},
+			second: []string{"Pick:", "  A", "> B"},
+			want:   "\r" + ansi.CursorUp(1) + "  A\r" + ansi.CursorDown(1) + "> B",
+		},
+		{
+			name:   "typed character",
+			first:  []string{"Name? bo"},
+			second: []string{"Name? bob"},
+			want:   "b",
+		},
+		{
+			name:   "shorter line",
+			first:  []string{"Name? bob"},
+			second: []string{"Name? b"},
+			want:   "\r" + ansi.CursorForward(7) + ansi.ClearToEnd,
+		},
+		{
+			name:   "fewer lines",
+			first:  []string{"Pick:", "> A", "  B"},
+			second: []string{"Pick:"},
+			want:   "\r" + ansi.CursorUp(1) + ansi.ClearFromCursorToEndScreen + ansi.CursorUp(1) + ansi.CursorForward(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, out := newSizedTerminal(20, 10)
+			r := NewRenderer(term)
+			r.Draw(tt.first)
+			out.Reset()
+
+			r.Draw(tt.second)
+			if got := out.String(); got != tt.want {
+				t.Errorf("Draw() wrote %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererResize(t *testing.T) {
+	term, out := newSizedTerminal(10, 10)
+	r := NewRenderer(term)
+	r.Draw([]string{"0123456789abc"})
+	if r.Rows() != 2 {
+		t.Fatalf("Rows() = %d; want 2", r.Rows())
+	}
+	out.Reset()
+
+	// The line now fits on one row, so the cursor was reflowed onto it
+	term.width = 20
+	r.Draw([]string{"0123456789abc"})
+
+	want := "\r" + ansi.ClearFromCursorToEndScreen + "0123456789abc"
+	if got := out.String(); got != want {
+		t.Errorf("Draw() wrote %q; want %q", got, want)
+	}
+	if r.Rows() != 1 {
+		t.Errorf("Rows() = %d; want 1", r.Rows())
+	}
+}
+
+func TestRendererFinish(t *testing.T) {
+	term, out := newSizedTerminal(20, 10)
+	r := NewRenderer(term)
+	r.Render([]string{"Name? bob", "error"}, 0, 9)
+	out.Reset()
+
+	r.Finish("Name? bob")
+
+	want := "\r" + ansi.CursorDown(1) + ansi.ClearFromCursorToEndScreen
+	if got := out.String(); got != want {
+		t.Errorf("Finish() wrote %q; want %q", got, want)
+	}
+	if r.Rows() != 0 {
+		t.Errorf("Rows() = %d; want 0 after Finish", r.Rows())
+	}
+}
+
+func TestRendererErase(t *testing.T) {
+	term, out := newSizedTerminal(20, 10)
+	r := NewRenderer(term)
+	r.Erase()
+	if out.Len() != 0 {
+		t.Errorf("Erase() before drawing wrote %q; want nothing", out.String())
+	}
+
+	r.Draw([]string{"Pick:", "> A", "  B"})
+	out.Reset()
+
+	r.Erase()
+	want := "\r" + ansi.CursorUp(2) + ansi.ClearFromCursorToEndScreen
+	if got := out.String(); got != want {
+		t.Errorf("Erase() wrote %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import "time"
+
+// resizeWatcher is implemented by terminals that report changes to their
+// size. WatchResize returns a channel receiving a value after each change
+// and a function that stops watching.
+type resizeWatcher interface {
+	WatchResize() (<-chan struct{}, func())
+}
+
+// watchResize starts watching t for size changes, if it supports it. The
+// returned channel never receives for terminals that can't be watched.
+func watchResize(t Terminal) (<-chan struct{}, func()) {
+	if w, ok := t.(resizeWatcher); ok {
+		return w.WatchResize()
+	}
+	return nil, func() {}
+}
+
+// resizePollInterval is how often terminal sizes are polled on systems
+// without resize signals.
+const resizePollInterval = 250 * time.Millisecond
+
+// pollResize watches for size changes by polling t.Size.
+func pollResize(t Terminal) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(resizePollInterval)
+		defer ticker.Stop()
+
+		width, height, _ := t.Size()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			w, h, err := t.Size()
+			if err != nil || (w == width && h == height) {
+				continue
+			}
+			width, height = w, h
+
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return ch, func() { close(done) }
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package tui
+
+// WatchResize reports changes to the window size by polling, as there is no
+// resize signal on this system.
+func (t *fileTerminal) WatchResize() (<-chan struct{}, func()) {
+	return pollResize(t)
+}
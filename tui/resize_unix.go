@@ -0,0 +1,38 @@
+//go:build unix
+
+package tui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WatchResize reports changes to the window size, signalled by SIGWINCH.
+func (t *fileTerminal) WatchResize() (<-chan struct{}, func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGWINCH)
+
+	ch := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-sigs:
+			}
+
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return ch, func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
@@ -2,11 +2,12 @@ package tui
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
+	"sync"
 
 	"github.com/engmtcdrm/go-ansi"
-	"golang.org/x/term"
+	"github.com/engmtcdrm/go-pardon/keys"
 )
 
 // Min returns the smaller of two integers.
@@ -17,28 +18,128 @@ func Min(a, b int) int {
 	return b
 }
 
-// ClearCurrentLine clears the current terminal line.
+// ScrollWindow keeps cursorPos inside a window of height rows over size items.
+// It returns the adjusted scroll offset and the exclusive end of the window.
+func ScrollWindow(cursorPos, scrollOffset, size, height int) (int, int) {
+	if height < 1 {
+		height = 1
+	}
+
+	if cursorPos < scrollOffset {
+		scrollOffset = cursorPos
+	} else if cursorPos >= scrollOffset+height {
+		scrollOffset = cursorPos - height + 1
+	}
+
+	return scrollOffset, Min(scrollOffset+height, size)
+}
+
+// FitWindow shrinks the window of items from start to the exclusive end
+// until the rows they take, as reported by rows, fit in height rows. Items
+// furthest from cursorPos are dropped first, and the item at cursorPos is
+// always kept. It returns the new start and end.
+func FitWindow(cursorPos, start, end, height int, rows func(int) int) (int, int) {
+	total := 0
+	for i := start; i < end; i++ {
+		total += rows(i)
+	}
+
+	for total > height && end-start > 1 {
+		if end-1 > cursorPos {
+			end--
+			total -= rows(end)
+		} else {
+			total -= rows(start)
+			start++
+		}
+	}
+	return start, end
+}
+
+// MoveListCursor applies a navigation key to a cursor over size items, of
+// which page are visible at once. Up and Down wrap around the ends of the
+// list. It returns false if the key is not a navigation key.
+func MoveListCursor(ev keys.Event, pos, size, page int) (int, bool) {
+	if size == 0 {
+		return pos, false
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	switch ev.Key {
+	case keys.Up:
+		return (pos + size - 1) % size, true
+	case keys.Down:
+		return (pos + 1) % size, true
+	case keys.PageUp:
+		return max(pos-page, 0), true
+	case keys.PageDown:
+		return Min(pos+page, size-1), true
+	case keys.Home:
+		return 0, true
+	case keys.End:
+		return size - 1, true
+	}
+
+	return pos, false
+}
+
+// FormatError formats a validation message for the error line below a prompt.
+func FormatError(msg string) string {
+	return fmt.Sprintf("%s* %s%s", ansi.Red, msg, ansi.Reset)
+}
+
+// FormatPlainError formats a validation message as plain text for
+// accessible mode, where it is read out by a screen reader.
+func FormatPlainError(msg string) string {
+	return "Error: " + msg
+}
+
+// ClearCurrentLine clears the current line of the default terminal.
 func ClearCurrentLine() {
-	fmt.Printf("\r%s", ansi.ClearLine)
+	FprintClearCurrentLine(DefaultTerminal())
 }
 
-// RenderFinalAnswer displays the final formatted prompt result.
+// FprintClearCurrentLine clears the current line of the terminal behind w.
+func FprintClearCurrentLine(w io.Writer) {
+	fmt.Fprintf(w, "\r%s", ansi.ClearLine)
+}
+
+// RenderFinalAnswer displays the final formatted prompt result on the
+// default terminal.
 func RenderFinalAnswer(icon, title, answer string) {
-	fmt.Printf("%s%s %s\n", icon, title, answer)
+	FprintFinalAnswer(DefaultTerminal(), icon, title, answer)
 }
 
-// RenderClearLines clears multiple lines from the cursor position.
+// FprintFinalAnswer writes the final formatted prompt result to w.
+func FprintFinalAnswer(w io.Writer, icon, title, answer string) {
+	fmt.Fprintf(w, "%s%s %s\n", icon, title, answer)
+}
+
+// RenderClearLines clears multiple lines of the default terminal from the
+// cursor position.
 func RenderClearLines(numLines int) {
+	FprintClearLines(DefaultTerminal(), numLines)
+}
+
+// FprintClearLines clears multiple lines from the cursor position of the
+// terminal behind w.
+func FprintClearLines(w io.Writer, numLines int) {
 	if numLines <= 0 {
 		return
 	}
 
 	sequence := ansi.ClearLine + "\r\n"
-	fmt.Print(strings.Repeat(sequence, numLines))
+	fmt.Fprint(w, strings.Repeat(sequence, numLines))
 }
 
-// RenderClearAndReposition clears lines and renders final answer.
-// Minimizes screen flicker by batching terminal operations.
+// RenderClearAndReposition clears lines and renders final answer on the
+// default terminal. Minimizes screen flicker by batching terminal operations.
+//
+// Deprecated: An answer wrapping onto more than one row leaves the cursor
+// in the wrong place. Use Renderer.Finish.
 func RenderClearAndReposition(linesToErase int, icon, title, answer string) {
 	var output strings.Builder
 
@@ -68,18 +169,107 @@ func RenderClearAndReposition(linesToErase int, icon, title, answer string) {
 	output.WriteString(ansi.ShowCursor)
 
 	// Write everything at once to minimize flicker
-	fmt.Print(output.String())
+	fmt.Fprint(DefaultTerminal(), output.String())
+}
+
+// GetInput reads a key from the default terminal and returns it as one of
+// the byte codes of the keys package, such as keys.KeyEnter, or as the
+// character typed. Characters beyond ASCII are returned a byte at a time
+// by successive calls. Keys without a code, and read errors, return 0.
+//
+// Deprecated: Arrow keys can't be told apart from the letters sharing
+// their codes. Use ReadKey or a KeyReader, which decode every key.
+func GetInput() byte {
+	pendingInput.Lock()
+	defer pendingInput.Unlock()
+
+	if len(pendingInput.b) == 0 {
+		ev, err := ReadKey(DefaultTerminal())
+		if err != nil {
+			return 0
+		}
+		pendingInput.b = keyBytes(ev)
+		if len(pendingInput.b) == 0 {
+			return 0
+		}
+	}
+
+	b := pendingInput.b[0]
+	pendingInput.b = pendingInput.b[1:]
+	return b
+}
+
+// pendingInput holds the bytes of a character GetInput has not returned yet.
+var pendingInput struct {
+	sync.Mutex
+	b []byte
 }
 
-// GetTerminalHeight returns the terminal height, defaulting to 25.
+// keyBytes returns the byte codes GetInput reports for ev.
+func keyBytes(ev keys.Event) []byte {
+	switch {
+	case ev.Key == keys.Up:
+		return []byte{keys.KeyUp}
+	case ev.Key == keys.Down:
+		return []byte{keys.KeyDown}
+	case ev.Key == keys.Right:
+		return []byte{keys.KeyRight}
+	case ev.Key == keys.Left:
+		return []byte{keys.KeyLeft}
+	case ev.Key == keys.Enter:
+		return []byte{keys.KeyEnter}
+	case ev.Key == keys.Backspace:
+		return []byte{keys.KeyBackspace}
+	case ev.Key == keys.Escape:
+		return []byte{keys.KeyEscape}
+	case ev.Key == keys.Tab:
+		return []byte{'\t'}
+	case ev.IsPrintable():
+		return []byte(string(ev.Rune))
+	case ev.Key == keys.Rune && ev.Mod == keys.ModCtrl && ev.Rune >= 'a' && ev.Rune <= 'z':
+		return []byte{byte(ev.Rune-'a') + 1}
+	}
+	return nil
+}
+
+// GetTerminalHeight returns the height of the default terminal, defaulting to 25.
 func GetTerminalHeight() int {
+	return TerminalHeight(DefaultTerminal())
+}
+
+// TerminalHeight returns the height of t, defaulting to 25.
+func TerminalHeight(t Terminal) int {
 	termHeight := 25 // Default height
-	if _, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+	if _, height, err := t.Size(); err == nil && height > 0 {
 		termHeight = height
 	}
 	return termHeight
 }
 
+// TerminalWidth returns the width of t, defaulting to 80.
+func TerminalWidth(t Terminal) int {
+	termWidth := 80 // Default width
+	if width, _, err := t.Size(); err == nil && width > 0 {
+		termWidth = width
+	}
+	return termWidth
+}
+
+// ScreenRows returns the number of screen rows the lines occupy on a
+// terminal width cells wide, counting lines too long for a row as the rows
+// they wrap onto.
+func ScreenRows(lines []string, width int) int {
+	if width < 1 {
+		width = 1
+	}
+
+	rows := 0
+	for _, line := range lines {
+		rows += lineRows(line, width)
+	}
+	return rows
+}
+
 // RenderFormattedOutput creates formatted output with ANSI clear sequences.
 func RenderFormattedOutput(question, result string) string {
 	return fmt.Sprintf("%s\r%s %s\n", ansi.ClearToBegin, question, result)
@@ -1,7 +1,10 @@
 package tui
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
 )
 
 func TestMin(t *testing.T) {
@@ -90,3 +93,132 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestScrollWindow(t *testing.T) {
+	tests := []struct {
+		name                    string
+		cursorPos, offset       int
+		size, height            int
+		wantOffset, wantEndExcl int
+	}{
+		{"fits entirely", 2, 0, 5, 10, 0, 5},
+		{"cursor below window", 7, 0, 10, 5, 3, 8},
+		{"cursor above window", 1, 4, 10, 5, 1, 6},
+		{"cursor inside window", 5, 3, 10, 5, 3, 8},
+		{"non-positive height", 3, 0, 10, 0, 3, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, end := ScrollWindow(tt.cursorPos, tt.offset, tt.size, tt.height)
+			if offset != tt.wantOffset || end != tt.wantEndExcl {
+				t.Errorf("ScrollWindow(%d, %d, %d, %d) = %d, %d; want %d, %d",
+					tt.cursorPos, tt.offset, tt.size, tt.height, offset, end, tt.wantOffset, tt.wantEndExcl)
+			}
+		})
+	}
+}
+
+func TestFitWindow(t *testing.T) {
+	rows := []int{1, 2, 1, 3, 1}
+	tests := []struct {
+		name               string
+		cursorPos          int
+		start, end, height int
+		wantStart, wantEnd int
+	}{
+		{"fits", 0, 0, 3, 4, 0, 3},
+		{"drops below cursor", 0, 0, 3, 3, 0, 2},
+		{"drops above cursor", 3, 1, 4, 4, 2, 4},
+		{"keeps cursor item too tall", 3, 2, 5, 1, 3, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := FitWindow(tt.cursorPos, tt.start, tt.end, tt.height, func(i int) int { return rows[i] })
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("FitWindow(%d, %d, %d, %d) = %d, %d; want %d, %d",
+					tt.cursorPos, tt.start, tt.end, tt.height, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	output := FormatError("value is required")
+
+	if !containsString(output, "* value is required") {
+		t.Errorf("FormatError() output doesn't contain the message\nOutput: %q", output)
+	}
+}
+
+func TestMoveListCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		ev     keys.Event
+		pos    int
+		want   int
+		wantOK bool
+	}{
+		{"up", keys.Named(keys.Up), 3, 2, true},
+		{"up wraps", keys.Named(keys.Up), 0, 9, true},
+		{"down", keys.Named(keys.Down), 3, 4, true},
+		{"down wraps", keys.Named(keys.Down), 9, 0, true},
+		{"page up", keys.Named(keys.PageUp), 6, 2, true},
+		{"page up clamps", keys.Named(keys.PageUp), 2, 0, true},
+		{"page down", keys.Named(keys.PageDown), 2, 6, true},
+		{"page down clamps", keys.Named(keys.PageDown), 8, 9, true},
+		{"home", keys.Named(keys.Home), 5, 0, true},
+		{"end", keys.Named(keys.End), 5, 9, true},
+		{"not navigation", keys.Char('j'), 5, 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MoveListCursor(tt.ev, tt.pos, 10, 4)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MoveListCursor(%v, %d) = %d, %t; want %d, %t", tt.ev, tt.pos, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := MoveListCursor(keys.Named(keys.Down), 0, 0, 4); ok {
+		t.Error("MoveListCursor() on an empty list should not move")
+	}
+}
+
+func TestFprintHelpers(t *testing.T) {
+	var out strings.Builder
+
+	FprintClearCurrentLine(&out)
+	FprintClearLines(&out, 2)
+	FprintClearLines(&out, -1)
+	FprintFinalAnswer(&out, "? ", "Name:", "Bob")
+
+	want := "\r\x1b[2K" + "\x1b[2K\r\n\x1b[2K\r\n" + "? Name: Bob\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+}
+
+func TestKeyBytes(t *testing.T) {
+	tests := []struct {
+		ev   keys.Event
+		want []byte
+	}{
+		{keys.Named(keys.Up), []byte{keys.KeyUp}},
+		{keys.Named(keys.Left), []byte{keys.KeyLeft}},
+		{keys.Named(keys.Enter), []byte{keys.KeyEnter}},
+		{keys.Named(keys.Backspace), []byte{keys.KeyBackspace}},
+		{keys.Ctrl('c'), []byte{keys.KeyCtrlC}},
+		{keys.Char('y'), []byte{'y'}},
+		{keys.Char('é'), []byte("é")},
+		{keys.Named(keys.F5), nil},
+	}
+
+	for _, tt := range tests {
+		if got := keyBytes(tt.ev); string(got) != string(tt.want) {
+			t.Errorf("keyBytes(%v) = %v; want %v", tt.ev, got, tt.want)
+		}
+	}
+}
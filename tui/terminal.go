@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal is returned when a terminal operation is not supported by the underlying streams.
+var ErrNotTerminal = errors.New("not a terminal")
+
+// Terminal abstracts the streams a prompt reads keystrokes from and renders to.
+type Terminal interface {
+	io.Reader
+	io.Writer
+
+	// MakeRaw puts the terminal into raw mode and returns a function that
+	// restores its previous state.
+	MakeRaw() (func() error, error)
+
+	// Size returns the width and height of the terminal in cells.
+	Size() (width, height int, err error)
+}
+
+// fileTerminal is a Terminal backed by operating system file handles.
+type fileTerminal struct {
+	in  *os.File
+	out *os.File
+
+	colorOnce sync.Once
+	colors    ColorLevel
+}
+
+// NewTerminal returns a Terminal that reads from in and writes to out.
+// Raw mode is applied to in and the size is queried from out, falling back to in.
+// Styles written are converted for the colors out supports, as detected by
+// DetectColorLevel the first time the terminal is written to.
+func NewTerminal(in, out *os.File) Terminal {
+	return &fileTerminal{in: in, out: out}
+}
+
+// Read reads input from the terminal.
+func (t *fileTerminal) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+// Write writes output to the terminal, converting styles for its colors.
+func (t *fileTerminal) Write(p []byte) (int, error) {
+	level := t.ColorLevel()
+	if level == ColorTrue {
+		return t.out.Write(p)
+	}
+
+	if _, err := io.WriteString(t.out, ConvertColors(string(p), level)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ColorLevel returns the colors supported by the output file.
+func (t *fileTerminal) ColorLevel() ColorLevel {
+	t.colorOnce.Do(func() {
+		t.colors = DetectColorLevel(term.IsTerminal(int(t.out.Fd())))
+	})
+	return t.colors
+}
+
+// MakeRaw puts the input file into raw mode. Output processing is kept,
+// as prompts are drawn while the terminal is raw.
+func (t *fileTerminal) MakeRaw() (func() error, error) {
+	fd := int(t.in.Fd())
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	keepOutputProcessing(fd)
+
+	return func() error { return term.Restore(fd, oldState) }, nil
+}
+
+// Size returns the dimensions of the output file, or of the input file if
+// the output is not a terminal.
+func (t *fileTerminal) Size() (int, int, error) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil {
+		return term.GetSize(int(t.in.Fd()))
+	}
+	return width, height, nil
+}
+
+// IsTerminal reports whether the input file is an interactive terminal.
+func (t *fileTerminal) IsTerminal() bool {
+	return term.IsTerminal(int(t.in.Fd()))
+}
+
+// streamTerminal is a Terminal over arbitrary streams without terminal control.
+type streamTerminal struct {
+	io.Reader
+	io.Writer
+}
+
+// NewStreamTerminal returns a Terminal over arbitrary streams such as a
+// network connection or in-memory buffers. Raw mode is a no-op and size
+// queries return ErrNotTerminal, so prompts use their default dimensions.
+func NewStreamTerminal(r io.Reader, w io.Writer) Terminal {
+	return &streamTerminal{Reader: r, Writer: w}
+}
+
+// MakeRaw does nothing as plain streams have no line discipline to change.
+func (t *streamTerminal) MakeRaw() (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// Size always fails as plain streams have no dimensions.
+func (t *streamTerminal) Size() (int, int, error) {
+	return 0, 0, ErrNotTerminal
+}
+
+// lineTerminal is a stream Terminal that prompts treat as non-interactive.
+type lineTerminal struct {
+	streamTerminal
+}
+
+// NewLineTerminal returns a Terminal over arbitrary streams on which prompts
+// read whole lines of input instead of individual keystrokes, as they do
+// when standard input is not a terminal.
+func NewLineTerminal(r io.Reader, w io.Writer) Terminal {
+	return &lineTerminal{streamTerminal{Reader: r, Writer: w}}
+}
+
+// IsTerminal always reports false so prompts use line-based input.
+func (t *lineTerminal) IsTerminal() bool {
+	return false
+}
+
+// IsInteractive reports whether prompts on t should read keystrokes and
+// redraw in place. Terminals that can't tell are assumed to be interactive.
+func IsInteractive(t Terminal) bool {
+	if it, ok := t.(interface{ IsTerminal() bool }); ok {
+		return it.IsTerminal()
+	}
+	return true
+}
+
+// stdTerminal is the Terminal connected to the process's standard streams.
+var stdTerminal = NewTerminal(os.Stdin, os.Stdout)
+
+// DefaultTerminal returns the Terminal connected to standard input and output.
+func DefaultTerminal() Terminal {
+	return stdTerminal
+}
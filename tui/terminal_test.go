@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// chunkReader returns one chunk per Read call, simulating individual
+// keystrokes. An empty chunk is a pause longer than escapeDelay.
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	if r.chunks[0] == "" {
+		time.Sleep(2 * escapeDelay)
+		r.chunks = r.chunks[1:]
+		return 0, nil
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func TestStreamTerminal(t *testing.T) {
+	var out bytes.Buffer
+	term := NewStreamTerminal(strings.NewReader("abc"), &out)
+
+	restore, err := term.MakeRaw()
+	if err != nil {
+		t.Fatalf("MakeRaw() error = %v", err)
+	}
+	if err := restore(); err != nil {
+		t.Errorf("restore() error = %v", err)
+	}
+
+	if _, _, err := term.Size(); !errors.Is(err, ErrNotTerminal) {
+		t.Errorf("Size() error = %v; want %v", err, ErrNotTerminal)
+	}
+
+	if _, err := io.WriteString(term, "hello"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if out.String() != "hello" {
+		t.Errorf("Written output = %q; want %q", out.String(), "hello")
+	}
+}
+
+func TestTerminalHeightDefault(t *testing.T) {
+	term := NewStreamTerminal(strings.NewReader(""), io.Discard)
+
+	if height := TerminalHeight(term); height != 25 {
+		t.Errorf("TerminalHeight() = %d; want default of 25", height)
+	}
+}
+
+func TestReadKeyFromTerminal(t *testing.T) {
+	term := NewStreamTerminal(&chunkReader{chunks: []string{"x", "\x1b[A", "A", "\x1b", "", "\x1b[", "5~", "hi"}}, io.Discard)
+
+	tests := []struct {
+		name string
+		want keys.Event
+	}{
+		{"plain character", keys.Char('x')},
+		{"arrow escape sequence", keys.Named(keys.Up)},
+		{"literal A", keys.Char('A')},
+		{"lone escape", keys.Named(keys.Escape)},
+		{"sequence split across reads", keys.Named(keys.PageUp)},
+		{"first character of a paste", keys.Char('h')},
+		{"buffered paste character", keys.Char('i')},
+	}
+
+	for _, tt := range tests {
+		got, err := ReadKey(term)
+		if err != nil {
+			t.Fatalf("%s: ReadKey() error = %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: ReadKey() = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ReadKey(term); !errors.Is(err, ErrEndOfInput) {
+		t.Errorf("ReadKey() at end of input error = %v; want %v", err, ErrEndOfInput)
+	}
+}
+
+func TestReadKeyContext(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	term := NewStreamTerminal(r, io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ReadKeyContext(ctx, term); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadKeyContext() error = %v; want %v", err, context.Canceled)
+	}
+
+	done := make(chan struct{})
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		defer close(done)
+		if _, err := ReadKeyContext(ctx, term); !errors.Is(err, context.Canceled) {
+			t.Errorf("ReadKeyContext() error = %v; want %v", err, context.Canceled)
+		}
+	}()
+	cancel()
+	<-done
+
+	// The abandoned read still delivers its input to the next caller
+	go w.Write([]byte("x"))
+
+	ev, err := ReadKeyContext(context.Background(), term)
+	if err != nil {
+		t.Fatalf("ReadKeyContext() error = %v", err)
+	}
+	if ev != keys.Char('x') {
+		t.Errorf("ReadKeyContext() = %v; want %v", ev, keys.Char('x'))
+	}
+}
@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/engmtcdrm/go-ansi"
+)
+
+// zeroWidthJoiner joins adjacent emoji into a single grapheme cluster.
+const zeroWidthJoiner = '\u200d'
+
+// wideRanges lists the East Asian Wide and Fullwidth code point ranges,
+// which occupy two terminal cells.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115f},   // Hangul Jamo initial consonants
+	{0x231a, 0x231b},   // Watch, hourglass
+	{0x2329, 0x232a},   // Angle brackets
+	{0x23e9, 0x23ec},   // Media control symbols
+	{0x23f0, 0x23f0},   // Alarm clock
+	{0x23f3, 0x23f3},   // Hourglass with flowing sand
+	{0x25fd, 0x25fe},   // Medium small squares
+	{0x2614, 0x2615},   // Umbrella, hot beverage
+	{0x2648, 0x2653},   // Zodiac signs
+	{0x267f, 0x267f},   // Wheelchair symbol
+	{0x2693, 0x2693},   // Anchor
+	{0x26a1, 0x26a1},   // High voltage
+	{0x26aa, 0x26ab},   // Medium circles
+	{0x26bd, 0x26be},   // Soccer ball, baseball
+	{0x26c4, 0x26c5},   // Snowman, sun behind cloud
+	{0x26ce, 0x26ce},   // Ophiuchus
+	{0x26d4, 0x26d4},   // No entry
+	{0x26ea, 0x26ea},   // Church
+	{0x26f2, 0x26f3},   // Fountain, flag in hole
+	{0x26f5, 0x26f5},   // Sailboat
+	{0x26fa, 0x26fa},   // Tent
+	{0x26fd, 0x26fd},   // Fuel pump
+	{0x2705, 0x2705},   // Check mark button
+	{0x270a, 0x270b},   // Raised fists
+	{0x2728, 0x2728},   // Sparkles
+	{0x274c, 0x274c},   // Cross mark
+	{0x274e, 0x274e},   // Cross mark button
+	{0x2753, 0x2755},   // Question and exclamation marks
+	{0x2757, 0x2757},   // Heavy exclamation mark
+	{0x2795, 0x2797},   // Heavy plus, minus, division
+	{0x27b0, 0x27b0},   // Curly loop
+	{0x27bf, 0x27bf},   // Double curly loop
+	{0x2b1b, 0x2b1c},   // Large squares
+	{0x2b50, 0x2b50},   // Star
+	{0x2b55, 0x2b55},   // Heavy large circle
+	{0x2e80, 0x303e},   // CJK radicals, Kangxi, CJK symbols and punctuation
+	{0x3041, 0x33ff},   // Hiragana, Katakana, Bopomofo, Hangul compatibility, CJK compatibility
+	{0x3400, 0x4dbf},   // CJK unified ideographs extension A
+	{0x4e00, 0x9fff},   // CJK unified ideographs
+	{0xa000, 0xa4cf},   // Yi syllables and radicals
+	{0xa960, 0xa97f},   // Hangul Jamo extended A
+	{0xac00, 0xd7a3},   // Hangul syllables
+	{0xf900, 0xfaff},   // CJK compatibility ideographs
+	{0xfe10, 0xfe19},   // Vertical forms
+	{0xfe30, 0xfe6f},   // CJK compatibility forms, small form variants
+	{0xff00, 0xff60},   // Fullwidth forms
+	{0xffe0, 0xffe6},   // Fullwidth signs
+	{0x16fe0, 0x16fe4}, // Ideographic symbols
+	{0x17000, 0x18cff}, // Tangut
+	{0x1b000, 0x1b2ff}, // Kana supplement and extensions, Nushu
+	{0x1f004, 0x1f004}, // Mahjong tile red dragon
+	{0x1f0cf, 0x1f0cf}, // Playing card black joker
+	{0x1f18e, 0x1f18e}, // Negative squared AB
+	{0x1f191, 0x1f19a}, // Squared CL to VS
+	{0x1f200, 0x1f251}, // Enclosed ideographic supplement
+	{0x1f300, 0x1f320}, // Weather and landscape symbols
+	{0x1f32d, 0x1f335}, // Food and plants
+	{0x1f337, 0x1f37c}, // Plants, food and drink
+	{0x1f37e, 0x1f393}, // Celebration
+	{0x1f3a0, 0x1f3ca}, // Activities
+	{0x1f3cf, 0x1f3d3}, // Sports
+	{0x1f3e0, 0x1f3f0}, // Buildings
+	{0x1f3f4, 0x1f3f4}, // Black flag
+	{0x1f3f8, 0x1f43e}, // Sports, animals
+	{0x1f440, 0x1f440}, // Eyes
+	{0x1f442, 0x1f4fc}, // People and objects
+	{0x1f4ff, 0x1f53d}, // Objects, symbols
+	{0x1f54b, 0x1f54e}, // Religious symbols
+	{0x1f550, 0x1f567}, // Clock faces
+	{0x1f57a, 0x1f57a}, // Man dancing
+	{0x1f595, 0x1f596}, // Hand gestures
+	{0x1f5a4, 0x1f5a4}, // Black heart
+	{0x1f5fb, 0x1f64f}, // Landmarks, emoticons
+	{0x1f680, 0x1f6c5}, // Transport and map symbols
+	{0x1f6cc, 0x1f6cc}, // Sleeping accommodation
+	{0x1f6d0, 0x1f6d2}, // Place of worship, shopping trolley
+	{0x1f6d5, 0x1f6d7}, // Hindu temple, hut, elevator
+	{0x1f6dc, 0x1f6df}, // Wireless, ring buoy
+	{0x1f6eb, 0x1f6ec}, // Airplane departure and arrival
+	{0x1f6f4, 0x1f6fc}, // Scooters, skateboard, roller skate
+	{0x1f7e0, 0x1f7eb}, // Large coloured circles and squares
+	{0x1f7f0, 0x1f7f0}, // Heavy equals sign
+	{0x1f90c, 0x1f93a}, // Supplemental symbols and pictographs
+	{0x1f93c, 0x1f945}, // Sports, objects
+	{0x1f947, 0x1f9ff}, // Medals, food, animals, people
+	{0x1fa70, 0x1faff}, // Symbols and pictographs extended A
+	{0x20000, 0x2fffd}, // CJK unified ideographs extensions B to F
+	{0x30000, 0x3fffd}, // CJK unified ideographs extension G and beyond
+}
+
+// isWide reports whether r is an East Asian Wide or Fullwidth character.
+func isWide(r rune) bool {
+	if r < wideRanges[0].lo {
+		return false
+	}
+
+	// Binary search over the sorted ranges
+	lo, hi := 0, len(wideRanges)-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < wideRanges[mid].lo:
+			hi = mid - 1
+		case r > wideRanges[mid].hi:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// isExtender reports whether r attaches to the preceding rune within a
+// grapheme cluster, such as a combining accent or variation selector.
+func isExtender(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0xfe00 && r <= 0xfe0f) || // Variation selectors
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // Emoji skin tone modifiers
+		(r >= 0xe0020 && r <= 0xe007f) || // Tag characters
+		(r >= 0xe0100 && r <= 0xe01ef) // Variation selectors supplement
+}
+
+// isRegionalIndicator reports whether r is half of a flag emoji.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// RuneWidth returns the number of terminal cells occupied by r on its own.
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0, r < 32, r >= 0x7f && r < 0xa0:
+		return 0
+	case isExtender(r), unicode.Is(unicode.Cf, r):
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// nextGrapheme returns the length in bytes of the grapheme cluster at the start of s.
+func nextGrapheme(s string) int {
+	if s == "" {
+		return 0
+	}
+
+	if len(s) >= 2 && s[0] == '\r' && s[1] == '\n' {
+		return 2
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	n := size
+
+	// A pair of regional indicators forms a single flag
+	if isRegionalIndicator(r) {
+		if next, nsize := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(next) {
+			n += nsize
+		}
+	}
+
+	for n < len(s) {
+		next, nsize := utf8.DecodeRuneInString(s[n:])
+		if !isExtender(next) {
+			break
+		}
+		n += nsize
+
+		// A zero width joiner glues the following rune onto the cluster
+		if next == zeroWidthJoiner && n < len(s) {
+			_, jsize := utf8.DecodeRuneInString(s[n:])
+			n += jsize
+		}
+	}
+
+	return n
+}
+
+// Graphemes splits s into user-perceived characters, keeping base
+// characters together with combining marks, emoji modifiers and joiners.
+func Graphemes(s string) []string {
+	clusters := make([]string, 0, len(s))
+	for len(s) > 0 {
+		n := nextGrapheme(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
+
+// emojiPresentation selects the emoji form of the character before it,
+// which is drawn two cells wide.
+const emojiPresentation = '\ufe0f'
+
+// graphemeWidth returns the number of terminal cells occupied by a grapheme
+// cluster. Flags and characters shown as emoji by a variation selector take
+// two cells, like other emoji.
+func graphemeWidth(g string) int {
+	r, size := utf8.DecodeRuneInString(g)
+	width := RuneWidth(r)
+	if width != 1 {
+		return width
+	}
+
+	if isRegionalIndicator(r) && len(g) > size {
+		return 2
+	}
+	for _, next := range g[size:] {
+		if next == emojiPresentation {
+			return 2
+		}
+	}
+	return width
+}
+
+// StringWidth returns the number of terminal cells needed to display s,
+// ignoring any ANSI escape codes.
+func StringWidth(s string) int {
+	s = ansi.StripCodes(s)
+
+	width := 0
+	for len(s) > 0 {
+		n := nextGrapheme(s)
+		width += graphemeWidth(s[:n])
+		s = s[n:]
+	}
+	return width
+}
+
+// lineRows returns the number of rows line takes on a terminal width cells
+// wide. A wide character that doesn't fit in the last cell of a row is
+// moved to the next one, leaving the cell empty.
+func lineRows(line string, width int) int {
+	row, _ := wrapCell(line, -1, width)
+	return row + 1
+}
+
+// wrapCell returns the row and column, relative to the start of line on a
+// terminal width cells wide, of the cell col cells into the line, where the
+// cursor is shown. A negative col stands for the end of the line. The end
+// of text filling a row exactly is reported as the column past the row, as
+// the terminal keeps the cursor on that row until more is written.
+func wrapCell(line string, col, width int) (int, int) {
+	width = max(width, 1)
+	s := ansi.StripCodes(line)
+
+	row, c, pos := 0, 0, 0
+	for len(s) > 0 {
+		n := nextGrapheme(s)
+		w := min(graphemeWidth(s[:n]), width)
+		if c+w > width {
+			row, c = row+1, 0
+		}
+		if pos == col {
+			return row, c
+		}
+
+		c += w
+		pos += w
+		s = s[n:]
+	}
+	return row, c
+}
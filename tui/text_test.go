@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWideRangesSorted(t *testing.T) {
+	for i, r := range wideRanges {
+		if r.lo > r.hi {
+			t.Errorf("Range %d is inverted: %#x > %#x", i, r.lo, r.hi)
+		}
+		if i > 0 && wideRanges[i-1].hi >= r.lo {
+			t.Errorf("Range %d overlaps or is out of order with range %d", i, i-1)
+		}
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want int
+	}{
+		{"ascii letter", 'a', 1},
+		{"latin accented", 'é', 1},
+		{"cyrillic", 'ж', 1},
+		{"control character", '\x07', 0},
+		{"combining acute accent", '\u0301', 0},
+		{"zero width joiner", '\u200d', 0},
+		{"variation selector", '\ufe0f', 0},
+		{"cjk ideograph", '日', 2},
+		{"hiragana", 'あ', 2},
+		{"hangul syllable", '한', 2},
+		{"fullwidth letter", 'Ａ', 2},
+		{"emoji", '😀', 2},
+		{"lock emoji", '🔒', 2},
+		{"box drawing", '─', 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RuneWidth(tt.r); got != tt.want {
+				t.Errorf("RuneWidth(%q) = %d; want %d", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"empty", "", []string{}},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"precomposed accent", "café", []string{"c", "a", "f", "é"}},
+		{"combining accent", "cafe\u0301", []string{"c", "a", "f", "e\u0301"}},
+		{"cjk", "日本", []string{"日", "本"}},
+		{"skin tone modifier", "👍🏽!", []string{"👍🏽", "!"}},
+		{"zwj sequence", "👩\u200d💻x", []string{"👩\u200d💻", "x"}},
+		{"flag", "🇯🇵🇫🇷", []string{"🇯🇵", "🇫🇷"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Graphemes(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Graphemes(%q) = %q; want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello", 5},
+		{"combining accent", "cafe\u0301", 4},
+		{"cjk", "日本語", 6},
+		{"mixed", "a日b", 4},
+		{"ansi codes ignored", "\x1b[31mred\x1b[0m", 3},
+		{"skin tone modifier", "👍🏽", 2},
+		{"flag", "🇯🇵", 2},
+		{"emoji presentation selector", "\u2764\ufe0f", 2},
+		{"keycap", "1\ufe0f\u20e3", 2},
+		{"text symbol", "\u2764", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringWidth(tt.s); got != tt.want {
+				t.Errorf("StringWidth(%q) = %d; want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCell(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		col     int
+		width   int
+		wantRow int
+		wantCol int
+	}{
+		{"start", "hello", 0, 10, 0, 0},
+		{"within first row", "hello", 3, 10, 0, 3},
+		{"end of line", "hello", -1, 10, 0, 5},
+		{"second row", "hello world", 7, 5, 1, 2},
+		{"end filling a row", "hello", -1, 5, 0, 5},
+		{"before text on next row", "helloworld", 5, 5, 1, 0},
+		{"wide character wraps whole", "abcd日", 4, 5, 1, 0},
+		{"end after wrapped wide character", "abcd日", -1, 5, 1, 2},
+		{"ansi codes ignored", "\x1b[31mab\x1b[0mc", 2, 10, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, col := wrapCell(tt.line, tt.col, tt.width)
+			if row != tt.wantRow || col != tt.wantCol {
+				t.Errorf("wrapCell(%q, %d, %d) = %d, %d; want %d, %d", tt.line, tt.col, tt.width, row, col, tt.wantRow, tt.wantCol)
+			}
+		})
+	}
+}
+
+func TestScreenRowsWide(t *testing.T) {
+	// Six cells fit in two rows of three, but neither wide character can
+	// start in the last cell of a row
+	if got := ScreenRows([]string{"ab日日"}, 3); got != 3 {
+		t.Errorf("ScreenRows() = %d; want 3", got)
+	}
+}
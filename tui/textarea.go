@@ -0,0 +1,336 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/engmtcdrm/go-ansi"
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+// textArea holds several lines of text being edited, with the editing
+// cursor on one of them.
+type textArea struct {
+	lines []*lineBuffer
+	row   int
+	goal  int // Column kept while moving up and down, or -1
+}
+
+// newTextArea creates a textArea holding s with the cursor at the end.
+func newTextArea(s string) *textArea {
+	parts := strings.Split(s, "\n")
+	lines := make([]*lineBuffer, len(parts))
+	for i, part := range parts {
+		lines[i] = newLineBuffer(part)
+	}
+	return &textArea{lines: lines, row: len(lines) - 1, goal: -1}
+}
+
+// String returns the full text, with lines separated by newlines.
+func (a *textArea) String() string {
+	parts := make([]string, len(a.lines))
+	for i, line := range a.lines {
+		parts[i] = line.String()
+	}
+	return strings.Join(parts, "\n")
+}
+
+// line returns the line the cursor is on.
+func (a *textArea) line() *lineBuffer {
+	return a.lines[a.row]
+}
+
+// Len returns the number of characters in the text, counting each line
+// break as one.
+func (a *textArea) Len() int {
+	n := len(a.lines) - 1
+	for _, line := range a.lines {
+		n += len(line.clusters)
+	}
+	return n
+}
+
+// Newline splits the line at the cursor, moving the cursor to the start of
+// the new line.
+func (a *textArea) Newline() {
+	cur := a.line()
+	rest := append([]string(nil), cur.clusters[cur.cursor:]...)
+	cur.clusters = cur.clusters[:cur.cursor]
+
+	a.row++
+	a.lines = append(a.lines[:a.row], append([]*lineBuffer{{clusters: rest}}, a.lines[a.row:]...)...)
+}
+
+// Backspace deletes the character left of the cursor, joining the line to
+// the previous one at its start.
+func (a *textArea) Backspace() {
+	cur := a.line()
+	if cur.cursor > 0 || a.row == 0 {
+		cur.Backspace()
+		return
+	}
+
+	a.row--
+	a.join()
+}
+
+// DeleteForward deletes the character under the cursor, joining the next
+// line to this one at its end.
+func (a *textArea) DeleteForward() {
+	cur := a.line()
+	if cur.cursor < len(cur.clusters) || a.row == len(a.lines)-1 {
+		cur.DeleteForward()
+		return
+	}
+
+	a.join()
+}
+
+// join appends the line after the cursor's to it, leaving the cursor
+// where the lines meet.
+func (a *textArea) join() {
+	cur, next := a.lines[a.row], a.lines[a.row+1]
+	cur.cursor = len(cur.clusters)
+	cur.clusters = append(cur.clusters, next.clusters...)
+	a.lines = append(a.lines[:a.row+1], a.lines[a.row+2:]...)
+}
+
+// Left moves the cursor one character left, onto the end of the previous
+// line from the start of a line.
+func (a *textArea) Left() {
+	if a.line().cursor == 0 && a.row > 0 {
+		a.row--
+		a.line().End()
+		return
+	}
+	a.line().Left()
+}
+
+// Right moves the cursor one character right, onto the start of the next
+// line from the end of a line.
+func (a *textArea) Right() {
+	if cur := a.line(); cur.cursor == len(cur.clusters) && a.row < len(a.lines)-1 {
+		a.row++
+		a.line().Home()
+		return
+	}
+	a.line().Right()
+}
+
+// Vertical moves the cursor up or down by rows, keeping it as near as the
+// line allows to the column it started from.
+func (a *textArea) Vertical(rows int) {
+	if a.goal < 0 {
+		a.goal = StringWidth(a.line().Before())
+	}
+
+	a.row = max(0, Min(a.row+rows, len(a.lines)-1))
+
+	line := a.line()
+	line.cursor = 0
+	for width := 0; line.cursor < len(line.clusters); line.cursor++ {
+		width += StringWidth(line.clusters[line.cursor])
+		if width > a.goal {
+			break
+		}
+	}
+}
+
+// editText applies an editing key to area, returning false if the key is
+// not an editing key. Keys that move or edit across lines are handled here
+// and the rest are those of a single line.
+func editText(area *textArea, ev keys.Event, page int) bool {
+	switch {
+	case ev.Key == keys.Up, ev == keys.Ctrl('p'):
+		area.Vertical(-1)
+		return true
+	case ev.Key == keys.Down, ev == keys.Ctrl('n'):
+		area.Vertical(1)
+		return true
+	case ev.Key == keys.PageUp:
+		area.Vertical(-page)
+		return true
+	case ev.Key == keys.PageDown:
+		area.Vertical(page)
+		return true
+	}
+
+	area.goal = -1
+	switch {
+	case ev.Key == keys.Backspace && ev.Mod&keys.ModAlt == 0:
+		area.Backspace()
+	case ev.Key == keys.Delete:
+		area.DeleteForward()
+	case ev.Key == keys.Left && ev.Mod&keys.ModCtrl == 0, ev == keys.Ctrl('b'):
+		area.Left()
+	case ev.Key == keys.Right && ev.Mod&keys.ModCtrl == 0, ev == keys.Ctrl('f'):
+		area.Right()
+	default:
+		return editLine(area.line(), ev)
+	}
+	return true
+}
+
+// textIndent is written before each line of multi-line input.
+const textIndent = "  "
+
+// displayText asks for multi-line input, showing the prompt with the lines
+// below it. Lines beyond the height scroll to keep the cursor in view.
+func (p *InputPrompt[T]) displayText(ctx context.Context, prompt string, value *T) error {
+	if !IsInteractive(p.term) || p.accessible {
+		return p.displayTextLines(ctx, prompt, value)
+	}
+
+	area := newTextArea(p.toStringFn(*value))
+	var lastError string
+	offset := 0
+
+	screen := NewRenderer(p.term)
+	redraw := func() {
+		// Keep room for the prompt and the error line
+		height := max(Min(p.height, TerminalHeight(p.term)-2), 1)
+
+		var end int
+		offset, end = ScrollWindow(area.row, offset, len(area.lines), height)
+
+		lines := []string{prompt + ansi.Dim + "[Ctrl-D to submit]" + ansi.ResetIntensity}
+		for _, line := range area.lines[offset:end] {
+			lines = append(lines, textIndent+line.String())
+		}
+		if lastError != "" {
+			lines = append(lines, FormatError(lastError))
+		}
+
+		screen.Render(lines, 1+area.row-offset, StringWidth(textIndent+area.line().Before()))
+	}
+
+	defer Guard(p.term)()
+	reader := NewKeyReader(p.term)
+	defer reader.Close()
+
+	redraw()
+
+	for {
+		ev, err := reader.ReadKey(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				screen.Erase()
+			}
+			return err
+		}
+
+		switch {
+		case ev == keys.Ctrl('d'), ev.Key == keys.Enter && ev.Mod&keys.ModAlt != 0:
+			input := p.fromStringFn(area.String())
+			if err := p.Check(input); err != nil {
+				lastError = err.Error()
+				redraw()
+				continue
+			}
+			*value = input
+			screen.Finish(strings.Split(prompt+p.answerFn(p.displayInputFn(input)), "\n")...)
+			return nil
+		case ev == keys.Ctrl('c'):
+			screen.Erase()
+			return ErrUserAborted
+		case ev.Key == keys.Resize:
+			redraw()
+			continue
+		case ev.Key == keys.Resume:
+			screen.Reset()
+			redraw()
+			continue
+		case ev.Key == keys.Enter:
+			if !p.fits(area, 1, 1) {
+				continue
+			}
+			area.goal = -1
+			area.Newline()
+		case ev.IsPrintable():
+			if p.acceptFn != nil && !p.acceptFn(ev.Rune) || !p.fits(area, 0, 1) {
+				continue
+			}
+			area.goal = -1
+			area.line().Insert(ev.Rune)
+		case editText(area, ev, max(p.height-1, 1)):
+		default:
+			continue
+		}
+
+		lastError = ""
+		redraw()
+	}
+}
+
+// fits reports whether adding lines and chars to area keeps it within the
+// line and character limits.
+func (p *InputPrompt[T]) fits(area *textArea, lines, chars int) bool {
+	if p.maxLines > 0 && len(area.lines)+lines > p.maxLines {
+		return false
+	}
+	return p.charLimit == 0 || area.Len()+chars <= p.charLimit
+}
+
+// displayTextLines asks for multi-line input a line at a time, for
+// terminals that are not interactive and for accessible mode. Input ends
+// with a line holding only a full stop, and ending it straight away keeps
+// the current value.
+func (p *InputPrompt[T]) displayTextLines(ctx context.Context, prompt string, value *T) error {
+	echoed := IsInteractive(p.term)
+
+	for {
+		fmt.Fprintln(p.term, strings.TrimRight(prompt, " "))
+		fmt.Fprintln(p.term, `Enter text, ending with a line holding only ".":`)
+
+		text, err := ReadText(ctx, p.term)
+		if err != nil {
+			return err
+		}
+
+		input := p.fromStringFn(text)
+		if text == "" {
+			input = *value
+		}
+
+		if err := p.Check(input); err != nil {
+			if !echoed {
+				fmt.Fprintln(p.term, p.displayInputFn(input))
+			}
+			if p.accessible {
+				fmt.Fprintln(p.term, FormatPlainError(err.Error()))
+			} else {
+				fmt.Fprintln(p.term, FormatError(err.Error()))
+			}
+			continue
+		}
+
+		*value = input
+		if !echoed {
+			fmt.Fprintln(p.term, p.answerFn(p.displayInputFn(input)))
+		}
+		return nil
+	}
+}
+
+// ReadText reads lines of input up to one holding only a full stop, or the
+// end of input, and returns them joined by newlines. ErrEndOfInput is
+// returned if input ends before any line is read.
+func ReadText(ctx context.Context, t Terminal) (string, error) {
+	var lines []string
+	for {
+		line, err := ReadLine(ctx, t)
+		if errors.Is(err, ErrEndOfInput) && len(lines) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if line == "." {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/engmtcdrm/go-pardon/keys"
+)
+
+func TestTextAreaEditing(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string
+		events  []keys.Event
+		want    string
+		row     int
+		cursor  int
+	}{
+		{"newline at end", "ab", []keys.Event{keys.Named(keys.Enter)}, "ab\n", 1, 0},
+		{"backspace joins", "ab\ncd", []keys.Event{keys.Named(keys.Home), keys.Named(keys.Backspace)}, "abcd", 0, 2},
+		{"backspace at start", "ab", []keys.Event{keys.Named(keys.Home), keys.Named(keys.Backspace)}, "ab", 0, 0},
+		{"delete joins", "ab\ncd", []keys.Event{keys.Named(keys.Up), keys.Named(keys.End), keys.Named(keys.Delete)}, "abcd", 0, 2},
+		{"right wraps", "ab\ncd", []keys.Event{keys.Named(keys.Up), keys.Named(keys.End), keys.Named(keys.Right)}, "ab\ncd", 1, 0},
+		{"down stops at last line", "ab\ncd", []keys.Event{keys.Named(keys.Down)}, "ab\ncd", 1, 2},
+		{"up to shorter line", "a\nbcd", []keys.Event{keys.Named(keys.Up)}, "a\nbcd", 0, 1},
+		{"goal column kept", "abcd\na\nabcd", []keys.Event{keys.Named(keys.Up), keys.Named(keys.Up)}, "abcd\na\nabcd", 0, 4},
+		{"wide characters", "日本\nabc", []keys.Event{keys.Named(keys.Left), keys.Named(keys.Up)}, "日本\nabc", 0, 1},
+		{"page up", "a\nb\nc\nd", []keys.Event{keys.Named(keys.PageUp)}, "a\nb\nc\nd", 1, 1},
+		{"word kill stays on line", "ab\ncd ef", []keys.Event{keys.Ctrl('w')}, "ab\ncd ", 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			area := newTextArea(tt.initial)
+			for _, ev := range tt.events {
+				if ev.Key == keys.Enter {
+					area.Newline()
+					continue
+				}
+				if !editText(area, ev, 2) {
+					t.Fatalf("editText(%v) = false; want true", ev)
+				}
+			}
+
+			if got := area.String(); got != tt.want {
+				t.Errorf("String() = %q; want %q", got, tt.want)
+			}
+			if area.row != tt.row || area.line().cursor != tt.cursor {
+				t.Errorf("cursor = %d, %d; want %d, %d", area.row, area.line().cursor, tt.row, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestTextAreaLen(t *testing.T) {
+	if got := newTextArea("ab\nc日").Len(); got != 5 {
+		t.Errorf("Len() = %d; want 5", got)
+	}
+}
+
+func TestInputPromptCheckLimits(t *testing.T) {
+	p := NewTextPrompt().MaxLines(2).CharLimit(5)
+
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"ab\ncd", ""},
+		{"a\nb\nc", "enter no more than 2 lines"},
+		{"abcdef", "enter no more than 5 characters"},
+	}
+
+	for _, tt := range tests {
+		err := p.Check(tt.input)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+			t.Errorf("Check(%q) error = %v; want %q", tt.input, err, tt.wantErr)
+		}
+	}
+}